@@ -0,0 +1,179 @@
+package dot
+
+// This file defines the removal of cycles from graphs by edge reversal, akin
+// to the acyclic tool of Graphviz.
+//
+// The feedback arc set is computed using the greedy heuristic described in
+// Eades, Lin & Smyth. 1993. A fast and effective heuristic for the feedback
+// arc set problem. https://doi.org/10.1016/0020-0190(93)90079-O
+
+// FeedbackArcSet returns a small set of directed arcs whose reversal makes the
+// graph acyclic. Self-loops and undirected edges are not considered.
+func (g *Graph) FeedbackArcSet() []Arc {
+	var arcs []Arc
+	for _, arc := range g.Arcs() {
+		if arc.Edge.Dir && arc.Src != arc.Dst {
+			arcs = append(arcs, arc)
+		}
+	}
+	pos := elsOrder(g, arcs)
+	var fas []Arc
+	for _, arc := range arcs {
+		if pos[arc.Src.Index] > pos[arc.Dst.Index] {
+			fas = append(fas, arc)
+		}
+	}
+	return fas
+}
+
+// elsOrder returns the position of each node, indexed by Node.Index, in a
+// vertex sequence with few leftward arcs, as computed by the Eades-Lin-Smyth
+// heuristic.
+func elsOrder(g *Graph, arcs []Arc) []int {
+	n := len(g.Nodes.Nodes)
+	in := make([][]Arc, n)
+	out := make([][]Arc, n)
+	indeg := make([]int, n)
+	outdeg := make([]int, n)
+	for _, arc := range arcs {
+		out[arc.Src.Index] = append(out[arc.Src.Index], arc)
+		in[arc.Dst.Index] = append(in[arc.Dst.Index], arc)
+		outdeg[arc.Src.Index]++
+		indeg[arc.Dst.Index]++
+	}
+	removed := make([]bool, n)
+	remove := func(v *Node) {
+		removed[v.Index] = true
+		for _, arc := range out[v.Index] {
+			indeg[arc.Dst.Index]--
+		}
+		for _, arc := range in[v.Index] {
+			outdeg[arc.Src.Index]--
+		}
+	}
+
+	// s1 is built from the left, and s2 from the right.
+	var s1, s2 []*Node
+	for left := n; left > 0; {
+		for changed := true; changed; {
+			changed = false
+			// Sinks are moved to the right.
+			for _, v := range g.Nodes.Nodes {
+				if !removed[v.Index] && outdeg[v.Index] == 0 {
+					remove(v)
+					s2 = append(s2, v)
+					left--
+					changed = true
+				}
+			}
+			// Sources are moved to the left.
+			for _, v := range g.Nodes.Nodes {
+				if !removed[v.Index] && indeg[v.Index] == 0 {
+					remove(v)
+					s1 = append(s1, v)
+					left--
+					changed = true
+				}
+			}
+		}
+		if left == 0 {
+			break
+		}
+		// Move the node with maximum outdegree minus indegree to the left.
+		var max *Node
+		for _, v := range g.Nodes.Nodes {
+			if removed[v.Index] {
+				continue
+			}
+			if max == nil || outdeg[v.Index]-indeg[v.Index] > outdeg[max.Index]-indeg[max.Index] {
+				max = v
+			}
+		}
+		remove(max)
+		s1 = append(s1, max)
+		left--
+	}
+
+	pos := make([]int, n)
+	for i, v := range s1 {
+		pos[v.Index] = i
+	}
+	for i, v := range s2 {
+		pos[v.Index] = n - 1 - i
+	}
+	return pos
+}
+
+// MakeAcyclic makes the graph acyclic by reversing the edges of a small
+// feedback arc set, and returns the reversed edges. Reversed edges are marked
+// with the dir=back attribute, so that they are drawn as before.
+//
+// Edges to or from subgraphs which are only partially reversed are replaced
+// by one edge per pair of nodes.
+//
+// NOTE: the dominator tree has to recalculated (e.g. buildDomTree) afterwards.
+func (g *Graph) MakeAcyclic() []*Edge {
+	backward := make(map[Arc]bool)
+	for _, arc := range g.FeedbackArcSet() {
+		backward[arc] = true
+	}
+	if len(backward) == 0 {
+		return nil
+	}
+	var reversed []*Edge
+	edges := make([]*Edge, len(g.Edges.Edges))
+	copy(edges, g.Edges.Edges)
+	for _, edge := range edges {
+		var fwd, back []Arc
+		for _, src := range g.endpoints(edge.Src) {
+			for _, dst := range g.endpoints(edge.Dst) {
+				arc := Arc{Src: src, Dst: dst, Edge: edge}
+				if backward[arc] {
+					back = append(back, arc)
+				} else {
+					fwd = append(fwd, arc)
+				}
+			}
+		}
+		switch {
+		case len(back) == 0:
+			continue
+		case len(fwd) == 0:
+			g.Edges.Reverse(edge)
+			markReversed(edge)
+			reversed = append(reversed, edge)
+		default:
+			// Split the edge into one edge per pair of nodes.
+			g.Edges.del(edge)
+			for _, arc := range fwd {
+				g.Edges.Add(nodeEdge(arc))
+			}
+			for _, arc := range back {
+				e := nodeEdge(arc)
+				e.Src, e.Dst = e.Dst, e.Src
+				e.SrcPort, e.DstPort = e.DstPort, e.SrcPort
+				markReversed(e)
+				g.Edges.Add(e)
+				reversed = append(reversed, e)
+			}
+		}
+	}
+	linkNodes(g)
+	return reversed
+}
+
+// markReversed updates the dir attribute of a reversed edge, so that it is
+// drawn in its original direction.
+func markReversed(edge *Edge) {
+	if edge.Attrs == nil {
+		edge.Attrs = NewAttrs()
+	}
+	switch edge.Attrs["dir"] {
+	case "back":
+		edge.Attrs["dir"] = "forward"
+	case "both", "none":
+		// Direction of arrowheads is unaffected.
+	default:
+		edge.Attrs["dir"] = "back"
+	}
+}
@@ -0,0 +1,42 @@
+package dot
+
+import "testing"
+
+func TestMakeAcyclic(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a -> b;
+		b -> c;
+		c -> a [color=red];
+		c -> d;
+		d -> d;
+	}`))
+	check(t, err)
+	fas := g.FeedbackArcSet()
+	assert(t, "size of feedback arc set", len(fas), 1)
+	reversed := g.MakeAcyclic()
+	assert(t, "number of reversed edges", len(reversed), 1)
+	edge := reversed[0]
+	assert(t, "reversed edge", edge.Src+"->"+edge.Dst, "a->c")
+	assert(t, "dir", edge.Attrs["dir"], "back")
+	assert(t, "color", edge.Attrs["color"], "red")
+	assert(t, "has edge", g.HasEdge("a", "c"), true)
+	assert(t, "has edge", g.HasEdge("c", "a"), false)
+	assert(t, "feedback arcs after reversal", len(g.FeedbackArcSet()), 0)
+	for _, comp := range g.StronglyConnectedComponents() {
+		assert(t, "component size", len(comp), 1)
+	}
+	assert(t, "self-loop", g.HasEdge("d", "d"), true)
+}
+
+func TestMakeAcyclicSubGraph(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a -> b;
+		{b c} -> a;
+		c -> d;
+	}`))
+	check(t, err)
+	reversed := g.MakeAcyclic()
+	assert(t, "number of reversed edges", len(reversed), 1)
+	assert(t, "reversed edge", reversed[0].Src+"->"+reversed[0].Dst, "a->b")
+	assert(t, "feedback arcs after reversal", len(g.FeedbackArcSet()), 0)
+}
@@ -25,26 +25,8 @@ func NewAnalysedGraph(graph *ast.Graph) *Graph {
 	g := NewGraph()
 	Analyse(graph, g)
 
-	// TODO: Make deterministic.
-
 	// Add edges between each node for the dominator tree construction.
-	for src, dsts := range g.Edges.SrcToDsts {
-		for dst := range dsts {
-			// Add edges between each node for the dominator tree construction.
-			from, ok := g.Nodes.Lookup[src]
-			if ok {
-				addEdges(g, from, dst)
-			} else if g.IsSubGraph(src) {
-				// Child nodes of the src SubGraph.
-				for srcNode := range g.Relations.ParentToChildren[src] {
-					from := g.Nodes.Lookup[srcNode]
-					addEdges(g, from, dst)
-				}
-			} else {
-				panic(fmt.Sprintf("unable to add edge from src %v", src))
-			}
-		}
-	}
+	linkNodes(g)
 
 	// Make sure that the "entry" node is first in the list.
 	for index, node := range g.Nodes.Nodes {
@@ -70,7 +52,7 @@ func addEdges(graph *Graph, from *Node, dst string) {
 		addEdge(from, to)
 	} else if graph.IsSubGraph(dst) {
 		// Child nodes of the dst SubGraph.
-		for dstNode := range graph.Relations.ParentToChildren[dst] {
+		for _, dstNode := range graph.Relations.SortedChildren(dst) {
 			to := graph.Nodes.Lookup[dstNode]
 			addEdge(from, to)
 		}
@@ -79,11 +61,46 @@ func addEdges(graph *Graph, from *Node, dst string) {
 	}
 }
 
+// linkNodes adds the predecessors and successors of each node of the graph, as
+// derived from its edges.
+func linkNodes(g *Graph) {
+	for _, node := range g.Nodes.Nodes {
+		node.Preds, node.Succs = nil, nil
+	}
+	for _, edge := range g.Edges.Sorted() {
+		// Add edges between each node for the dominator tree construction.
+		from, ok := g.Nodes.Lookup[edge.Src]
+		if ok {
+			addEdges(g, from, edge.Dst)
+		} else if g.IsSubGraph(edge.Src) {
+			// Child nodes of the src SubGraph.
+			for _, srcNode := range g.Relations.SortedChildren(edge.Src) {
+				from := g.Nodes.Lookup[srcNode]
+				addEdges(g, from, edge.Dst)
+			}
+		} else {
+			panic(fmt.Sprintf("unable to add edge from src %v", edge.Src))
+		}
+	}
+}
+
 //Analyses an Abstract Syntax Tree representing a parsed graph into a newly created graph structure Interface.
+//A Graph records the attributes whose values are HTML strings in the HTMLAttrs
+//of its elements; other implementations of Interface are given the values of
+//HTML strings including their angle brackets, as with other IDs.
 func Analyse(graph *ast.Graph, g Interface) {
 	graph.Walk(&graphVisitor{g})
 }
 
+// htmlGraph is implemented by graphs which record the attributes whose values
+// are HTML strings, i.e. Graph.
+type htmlGraph interface {
+	addPortEdge(src, srcPort, dst, dstPort string, directed bool, attrs Attrs, html HTMLAttrs)
+	addNode(parentGraph string, name string, attrs Attrs, html HTMLAttrs)
+	addAttr(parentGraph string, field string, value string, html bool)
+	addSubGraph(parentGraph string, name string, attrs Attrs, html HTMLAttrs)
+}
+
 type nilVisitor struct {
 }
 
@@ -102,7 +119,7 @@ func (this *graphVisitor) Visit(v ast.Elem) ast.Visitor {
 	}
 	this.g.SetStrict(graph.Strict)
 	this.g.SetDir(graph.Type == ast.DIGRAPH)
-	graphName := graph.Id.String()
+	graphName := unquoteId(graph.Id.String())
 	this.g.SetName(graphName)
 	return newStmtVisitor(this.g, graphName)
 }
@@ -111,6 +128,9 @@ func newStmtVisitor(g Interface, graphName string) *stmtVisitor {
 	return &stmtVisitor{g, graphName, make(Attrs), make(Attrs), make(Attrs)}
 }
 
+// stmtVisitor analyses the statements of a graph or subgraph. The values of
+// attributes are kept as DOT IDs until added to the graph, so that HTML strings
+// may be told apart from quoted strings.
 type stmtVisitor struct {
 	g                 Interface
 	graphName         string
@@ -160,30 +180,30 @@ func overwrite(attrs Attrs, overwrite Attrs) Attrs {
 }
 
 func (this *stmtVisitor) nodeStmt(stmt ast.NodeStmt) ast.Visitor {
-	attrs := Attrs(stmt.Attrs.GetMap())
+	attrs := attrIds(stmt.Attrs)
 	attrs = ammend(attrs, this.currentNodeAttrs)
-	this.g.AddNode(this.graphName, stmt.NodeId.String(), attrs)
+	this.addNode(unquoteId(stmt.NodeId.Id.String()), attrs)
 	return &nilVisitor{}
 }
 
 func (this *stmtVisitor) edgeStmt(stmt ast.EdgeStmt) ast.Visitor {
-	attrs := stmt.Attrs.GetMap()
+	attrs := attrIds(stmt.Attrs)
 	attrs = ammend(attrs, this.currentEdgeAttrs)
 	src := stmt.Source.GetId()
-	srcName := src.String()
+	srcName := unquoteId(src.String())
 	if stmt.Source.IsNode() {
-		this.g.AddNode(this.graphName, srcName, this.currentNodeAttrs.Copy())
+		this.addNode(srcName, this.currentNodeAttrs)
 	}
-	srcPort := stmt.Source.GetPort()
+	srcPort := unquotePort(stmt.Source.GetPort())
 	for i := range stmt.EdgeRHS {
 		directed := bool(stmt.EdgeRHS[i].Op)
 		dst := stmt.EdgeRHS[i].Destination.GetId()
-		dstName := dst.String()
+		dstName := unquoteId(dst.String())
 		if stmt.EdgeRHS[i].Destination.IsNode() {
-			this.g.AddNode(this.graphName, dstName, this.currentNodeAttrs.Copy())
+			this.addNode(dstName, this.currentNodeAttrs)
 		}
-		dstPort := stmt.EdgeRHS[i].Destination.GetPort()
-		this.g.AddPortEdge(srcName, srcPort.String(), dstName, dstPort.String(), directed, attrs)
+		dstPort := unquotePort(stmt.EdgeRHS[i].Destination.GetPort())
+		this.addPortEdge(srcName, srcPort, dstName, dstPort, directed, attrs)
 		src = dst
 		srcPort = dstPort
 		srcName = dstName
@@ -192,31 +212,104 @@ func (this *stmtVisitor) edgeStmt(stmt ast.EdgeStmt) ast.Visitor {
 }
 
 func (this *stmtVisitor) nodeAttrs(stmt ast.NodeAttrs) ast.Visitor {
-	this.currentNodeAttrs = overwrite(this.currentNodeAttrs, ast.AttrList(stmt).GetMap())
+	this.currentNodeAttrs = overwrite(this.currentNodeAttrs, attrIds(ast.AttrList(stmt)))
 	return &nilVisitor{}
 }
 
 func (this *stmtVisitor) edgeAttrs(stmt ast.EdgeAttrs) ast.Visitor {
-	this.currentEdgeAttrs = overwrite(this.currentEdgeAttrs, ast.AttrList(stmt).GetMap())
+	this.currentEdgeAttrs = overwrite(this.currentEdgeAttrs, attrIds(ast.AttrList(stmt)))
 	return &nilVisitor{}
 }
 
 func (this *stmtVisitor) graphAttrs(stmt ast.GraphAttrs) ast.Visitor {
-	attrs := ast.AttrList(stmt).GetMap()
+	attrs := attrIds(ast.AttrList(stmt))
 	for key, value := range attrs {
-		this.g.AddAttr(this.graphName, key, value)
+		this.addAttr(this.graphName, key, value)
 	}
 	this.currentGraphAttrs = overwrite(this.currentGraphAttrs, attrs)
 	return &nilVisitor{}
 }
 
 func (this *stmtVisitor) subGraph(stmt *ast.SubGraph) ast.Visitor {
-	subGraphName := stmt.Id.String()
-	this.g.AddSubGraph(this.graphName, subGraphName, this.currentGraphAttrs)
+	subGraphName := unquoteId(stmt.Id.String())
+	this.addSubGraph(subGraphName, this.currentGraphAttrs)
 	return newStmtVisitor(this.g, subGraphName)
 }
 
 func (this *stmtVisitor) attr(stmt *ast.Attr) ast.Visitor {
-	this.g.AddAttr(this.graphName, stmt.Field.String(), stmt.Value.String())
+	this.addAttr(this.graphName, unquoteId(stmt.Field.String()), stmt.Value.String())
 	return this
 }
+
+// addPortEdge adds an edge with the given attributes, whose values are DOT IDs.
+func (this *stmtVisitor) addPortEdge(src, srcPort, dst, dstPort string, directed bool, ids Attrs) {
+	attrs, html := unquoteValues(ids)
+	if g, ok := this.g.(htmlGraph); ok {
+		g.addPortEdge(src, srcPort, dst, dstPort, directed, attrs, html)
+		return
+	}
+	this.g.AddPortEdge(src, srcPort, dst, dstPort, directed, attrs)
+}
+
+// addNode adds the named node with the given attributes, whose values are DOT
+// IDs, to the current graph or subgraph.
+func (this *stmtVisitor) addNode(name string, ids Attrs) {
+	attrs, html := unquoteValues(ids)
+	if g, ok := this.g.(htmlGraph); ok {
+		g.addNode(this.graphName, name, attrs, html)
+		return
+	}
+	this.g.AddNode(this.graphName, name, attrs)
+}
+
+// addAttr adds the named attribute, whose value is a DOT ID, to the given graph
+// or subgraph.
+func (this *stmtVisitor) addAttr(graphName, name, id string) {
+	value := unquoteId(id)
+	if g, ok := this.g.(htmlGraph); ok {
+		g.addAttr(graphName, name, value, isHtmlId(id))
+		return
+	}
+	this.g.AddAttr(graphName, name, value)
+}
+
+// addSubGraph adds the named subgraph with the given attributes, whose values
+// are DOT IDs, to the current graph or subgraph.
+func (this *stmtVisitor) addSubGraph(name string, ids Attrs) {
+	attrs, html := unquoteValues(ids)
+	if g, ok := this.g.(htmlGraph); ok {
+		g.addSubGraph(this.graphName, name, attrs, html)
+		return
+	}
+	this.g.AddSubGraph(this.graphName, name, attrs)
+}
+
+// attrIds returns the unquoted names of the given attributes, and the DOT IDs
+// of their values.
+func attrIds(list ast.AttrList) Attrs {
+	attrs := make(Attrs)
+	for name, value := range list.GetMap() {
+		attrs[unquoteId(name)] = value
+	}
+	return attrs
+}
+
+// unquoteValues returns the given attributes with their values, which are DOT
+// IDs, unquoted, and the names of those whose values are HTML strings.
+func unquoteValues(ids Attrs) (Attrs, HTMLAttrs) {
+	attrs := make(Attrs)
+	var html HTMLAttrs
+	for name, id := range ids {
+		attrs[name] = unquoteId(id)
+		if isHtmlId(id) {
+			html.set(name, true)
+		}
+	}
+	return attrs, html
+}
+
+// unquotePort returns the given port of the form ":id" or ":id:compass_pt",
+// with its ID and compass point unquoted.
+func unquotePort(port ast.Port) string {
+	return joinPort(unquoteId(port.Id1.String()), unquoteId(port.Id2.String()))
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 )
 
 //Represents attributes for an Edge, Node or Graph.
@@ -28,6 +29,35 @@ func NewAttrs() Attrs {
 	return make(Attrs)
 }
 
+// HTMLAttrs is the set of names of the attributes of a graph, subgraph, node or
+// edge whose values are HTML strings, e.g. <<b>bold</b>>, rather than quoted
+// strings. The values of HTML strings include their enclosing angle brackets.
+type HTMLAttrs map[string]bool
+
+// Copy returns a copy of the set, or nil if empty.
+func (this HTMLAttrs) Copy() HTMLAttrs {
+	var html HTMLAttrs
+	for name, ok := range this {
+		if ok {
+			html.set(name, true)
+		}
+	}
+	return html
+}
+
+// set adds the named attribute to the set if html is true, or removes it
+// otherwise.
+func (this *HTMLAttrs) set(name string, html bool) {
+	switch {
+	case html && *this == nil:
+		*this = HTMLAttrs{name: true}
+	case html:
+		(*this)[name] = true
+	default:
+		delete(*this, name)
+	}
+}
+
 //Adds an attribute name and value.
 func (this Attrs) Add(field string, value string) {
 	prev, ok := this[field]
@@ -69,3 +99,39 @@ func (this Attrs) Copy() Attrs {
 	}
 	return attrs
 }
+
+// Float returns the value of the named attribute as a floating-point number.
+// The boolean result reports whether the attribute is present.
+func (this Attrs) Float(name string) (float64, bool, error) {
+	value, ok := this[name]
+	if !ok {
+		return 0, false, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid value %q of attribute %q; %v", value, name, err)
+	}
+	return f, true, nil
+}
+
+// SetNodeAttr sets the named attribute of each given node, e.g. to highlight
+// the result of an analysis.
+func SetNodeAttr(nodes []*Node, name, value string) {
+	for _, node := range nodes {
+		if node.Attrs == nil {
+			node.Attrs = NewAttrs()
+		}
+		node.Attrs[name] = value
+	}
+}
+
+// SetEdgeAttr sets the named attribute of each given edge, e.g. to highlight
+// the result of an analysis.
+func SetEdgeAttr(edges []*Edge, name, value string) {
+	for _, edge := range edges {
+		if edge.Attrs == nil {
+			edge.Attrs = NewAttrs()
+		}
+		edge.Attrs[name] = value
+	}
+}
@@ -0,0 +1,180 @@
+package dot
+
+// This file defines the biconnected decomposition of graphs, using the
+// algorithm described in Hopcroft & Tarjan. 1973. Algorithm 447: efficient
+// algorithms for graph manipulation. https://doi.org/10.1145/362248.362272
+
+// Biconnectivity is the biconnected decomposition of a graph. The direction of
+// edges is ignored.
+//
+// The results may be highlighted by setting attributes, e.g.
+//
+//	SetNodeAttr(b.ArticulationPoints, "color", "red")
+//	SetEdgeAttr(b.Bridges, "color", "red")
+type Biconnectivity struct {
+	// ArticulationPoints contains the cut vertices of the graph, whose removal
+	// disconnects their component, in node order.
+	ArticulationPoints []*Node
+	// Bridges contains the edges whose removal disconnects their component, in
+	// edge order. An edge to or from a subgraph is a bridge if it connects any
+	// pair of nodes by a bridge.
+	Bridges []*Edge
+	// Blocks contains the biconnected components of the graph. Isolated nodes
+	// and self-loops are not part of any block.
+	Blocks []*Block
+}
+
+// Block is a biconnected component of a graph; i.e. a maximal subgraph which
+// remains connected after the removal of any one node.
+type Block struct {
+	// Nodes of the block, in node order.
+	Nodes []*Node
+	// Edges of the block, in edge order.
+	Edges []*Edge
+}
+
+// Biconnected computes the articulation points, bridges and biconnected
+// components of the graph, ignoring the direction of edges.
+func (g *Graph) Biconnected() *Biconnectivity {
+	n := len(g.Nodes.Nodes)
+	s := &bccState{
+		g:       g,
+		bridges: make(map[*Edge]bool),
+		adj:     make([][]int, n),
+		disc:    make([]int, n),
+		low:     make([]int, n),
+		cut:     make([]bool, n),
+	}
+	for _, edge := range g.Edges.Edges {
+		for _, src := range g.endpoints(edge.Src) {
+			for _, dst := range g.endpoints(edge.Dst) {
+				if src == dst {
+					continue
+				}
+				l := len(s.links)
+				s.links = append(s.links, Arc{Src: src, Dst: dst, Edge: edge})
+				s.adj[src.Index] = append(s.adj[src.Index], l)
+				s.adj[dst.Index] = append(s.adj[dst.Index], l)
+			}
+		}
+	}
+	for i := range s.disc {
+		s.disc[i] = -1
+	}
+	for _, root := range g.Nodes.Nodes {
+		if s.disc[root.Index] != -1 {
+			continue
+		}
+		if children := s.visit(root, -1); children > 1 {
+			s.cut[root.Index] = true
+		}
+	}
+
+	b := &Biconnectivity{Blocks: s.blocks}
+	for _, node := range g.Nodes.Nodes {
+		if s.cut[node.Index] {
+			b.ArticulationPoints = append(b.ArticulationPoints, node)
+		}
+	}
+	for _, edge := range g.Edges.Edges {
+		if s.bridges[edge] {
+			b.Bridges = append(b.Bridges, edge)
+			// Only report each edge once.
+			delete(s.bridges, edge)
+		}
+	}
+	return b
+}
+
+// bccState holds the working state for the biconnected components algorithm.
+type bccState struct {
+	g *Graph
+	// Connections between distinct nodes, regardless of direction.
+	links []Arc
+	// Stack of visited links, indices into links.
+	stack   []int
+	time    int
+	blocks  []*Block
+	bridges map[*Edge]bool
+
+	// Each slice is indexed by Node.Index.
+	adj  [][]int // incident links, indices into links
+	disc []int   // discovery time; -1 if not yet visited
+	low  []int   // least discovery time reachable through a back edge
+	cut  []bool  // articulation point
+}
+
+// visit implements the recursive part of the biconnected components algorithm,
+// visiting v through the link with index parent (-1 for the root). It returns
+// the number of children of v in the depth-first spanning tree.
+func (s *bccState) visit(v *Node, parent int) int {
+	s.disc[v.Index] = s.time
+	s.low[v.Index] = s.time
+	s.time++
+	children := 0
+	for _, l := range s.adj[v.Index] {
+		if l == parent {
+			continue
+		}
+		w := s.links[l].Dst
+		if w == v {
+			w = s.links[l].Src
+		}
+		if s.disc[w.Index] == -1 {
+			s.stack = append(s.stack, l)
+			children++
+			s.visit(w, l)
+			if s.low[w.Index] < s.low[v.Index] {
+				s.low[v.Index] = s.low[w.Index]
+			}
+			if s.low[w.Index] >= s.disc[v.Index] {
+				// v separates the subtree of w from the rest of the graph.
+				if parent != -1 {
+					s.cut[v.Index] = true
+				}
+				s.popBlock(l)
+			}
+			if s.low[w.Index] > s.disc[v.Index] {
+				s.bridges[s.links[l].Edge] = true
+			}
+		} else if s.disc[w.Index] < s.disc[v.Index] {
+			// Back edge to an ancestor.
+			s.stack = append(s.stack, l)
+			if s.disc[w.Index] < s.low[v.Index] {
+				s.low[v.Index] = s.disc[w.Index]
+			}
+		}
+	}
+	return children
+}
+
+// popBlock pops the links of a block off the stack, up to and including the
+// tree link with index l.
+func (s *bccState) popBlock(l int) {
+	nodes := make([]bool, len(s.g.Nodes.Nodes))
+	edges := make(map[*Edge]bool)
+	for {
+		top := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		link := s.links[top]
+		nodes[link.Src.Index] = true
+		nodes[link.Dst.Index] = true
+		edges[link.Edge] = true
+		if top == l {
+			break
+		}
+	}
+	block := &Block{}
+	for _, node := range s.g.Nodes.Nodes {
+		if nodes[node.Index] {
+			block.Nodes = append(block.Nodes, node)
+		}
+	}
+	for _, edge := range s.g.Edges.Edges {
+		if edges[edge] {
+			block.Edges = append(block.Edges, edge)
+			delete(edges, edge)
+		}
+	}
+	s.blocks = append(s.blocks, block)
+}
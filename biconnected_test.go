@@ -0,0 +1,35 @@
+package dot
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBiconnected(t *testing.T) {
+	g, err := Read([]byte(`graph G {
+		a -- b -- c -- a;
+		c -- d;
+		d -- e -- f -- d;
+		f -- g;
+		g -- h;
+		g -- h;
+		i;
+	}`))
+	check(t, err)
+	b := g.Biconnected()
+	assert(t, "articulation points", nodeNames(b.ArticulationPoints), "[c d f g]")
+	assert(t, "number of bridges", len(b.Bridges), 2)
+	assert(t, "bridge", b.Bridges[0].Src+"--"+b.Bridges[0].Dst, "c--d")
+	assert(t, "bridge", b.Bridges[1].Src+"--"+b.Bridges[1].Dst, "f--g")
+	assert(t, "number of blocks", len(b.Blocks), 5)
+	var blocks []string
+	for _, block := range b.Blocks {
+		blocks = append(blocks, nodeNames(block.Nodes))
+	}
+	assert(t, "blocks", fmt.Sprint(blocks), "[[g h] [f g] [d e f] [c d] [a b c]]")
+
+	SetNodeAttr(b.ArticulationPoints, "color", "red")
+	SetEdgeAttr(b.Bridges, "color", "red")
+	assert(t, "node attribute", g.Nodes.Lookup["c"].Attrs["color"], "red")
+	assert(t, "edge attribute", g.Edges.SrcToDsts["f"]["g"].Attrs["color"], "red")
+}
@@ -0,0 +1,230 @@
+package dot
+
+// This file defines a fluent API for building graphs.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Builder builds a graph using chained method calls, as in
+//
+//	g, err := NewBuilder().Digraph("G").
+//		Node("a").Attr("shape", "box").
+//		Edge("a", "b").Attr("color", "red").
+//		Cluster("c", func(c *Builder) {
+//			c.Attr("label", "cluster").Node("x").Node("y")
+//		}).
+//		Build()
+//
+// Attr sets an attribute of the most recently added element, or of the graph
+// or subgraph being built if none. Mistakes, e.g. invalid attributes or edges
+// with ports of subgraphs, are reported by Build; subsequent calls after a
+// mistake have no effect.
+type Builder struct {
+	g *Graph
+	// First mistake, shared with the builders of subgraphs.
+	err *error
+	// Name of the graph or subgraph being built.
+	parent string
+	// Attributes, context and description of the element to which Attr
+	// applies.
+	attrs Attrs
+	ctx   Context
+	elem  string
+}
+
+// NewBuilder returns a new builder of an unnamed undirected graph.
+func NewBuilder() *Builder {
+	b := &Builder{g: NewGraph(), err: new(error)}
+	b.target(b.g.Attrs, ContextGraph, "graph")
+	return b
+}
+
+// Digraph sets the graph to be directed, with the given name.
+func (b *Builder) Digraph(name string) *Builder {
+	return b.root(name, true)
+}
+
+// Graph sets the graph to be undirected, with the given name.
+func (b *Builder) Graph(name string) *Builder {
+	return b.root(name, false)
+}
+
+// root sets the name and direction of the graph, which must precede the
+// addition of any elements.
+func (b *Builder) root(name string, directed bool) *Builder {
+	if *b.err != nil {
+		return b
+	}
+	switch {
+	case b.parent != b.g.Name:
+		return b.fail("subgraph %s: name and direction may only be set for the graph", Quote(b.parent))
+	case len(b.g.Nodes.Nodes) > 0 || len(b.g.SubGraphs.SubGraphs) > 0:
+		return b.fail("graph %s: name and direction must be set before adding elements", Quote(name))
+	}
+	b.g.SetName(name)
+	b.g.SetDir(directed)
+	b.parent = name
+	return b.target(b.g.Attrs, ContextGraph, graphElem(name))
+}
+
+// Strict sets the graph to be strict; i.e. without multi-edges.
+func (b *Builder) Strict() *Builder {
+	b.g.SetStrict(true)
+	return b
+}
+
+// Node adds the named node to the graph or subgraph being built, or adds an
+// existing node to it.
+func (b *Builder) Node(name string) *Builder {
+	if *b.err != nil {
+		return b
+	}
+	if err := b.addNode(name); err != nil {
+		return b.fail("%v", err)
+	}
+	return b.target(b.g.Nodes.Lookup[name].Attrs, ContextNode, "node "+Quote(name))
+}
+
+// addNode adds the named node to the graph or subgraph being built.
+func (b *Builder) addNode(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("empty node name")
+	case b.g.IsSubGraph(name):
+		return fmt.Errorf("node %s: name already used by a subgraph", Quote(name))
+	}
+	b.g.AddNode(b.parent, name, NewAttrs())
+	if node := b.g.Nodes.Lookup[name]; node.Attrs == nil {
+		node.Attrs = NewAttrs()
+	}
+	return nil
+}
+
+// Edge adds an edge between the given nodes or subgraphs. Missing nodes are
+// added to the graph or subgraph being built.
+func (b *Builder) Edge(src, dst string) *Builder {
+	return b.PortEdge(src, "", dst, "")
+}
+
+// PortEdge adds an edge between the given ports of nodes, which are of the form
+// "id", "id:compass_pt" or "compass_pt", or empty if none. Missing nodes are
+// added to the graph or subgraph being built.
+func (b *Builder) PortEdge(src, srcPort, dst, dstPort string) *Builder {
+	if *b.err != nil {
+		return b
+	}
+	for _, end := range []struct{ name, port string }{{src, srcPort}, {dst, dstPort}} {
+		if b.g.IsSubGraph(end.name) {
+			if end.port != "" {
+				return b.fail("edge endpoint %s: subgraphs do not have ports", Quote(end.name))
+			}
+			continue
+		}
+		if err := b.addNode(end.name); err != nil {
+			return b.fail("%v", err)
+		}
+	}
+	edge := &Edge{Src: src, Dst: dst, Dir: b.g.Directed, Attrs: NewAttrs()}
+	if srcPort != "" {
+		edge.SrcPort = ":" + srcPort
+	}
+	if dstPort != "" {
+		edge.DstPort = ":" + dstPort
+	}
+	b.g.Edges.Add(edge)
+	return b.target(edge.Attrs, ContextEdge, "edge "+edgeString(edge))
+}
+
+// Subgraph adds the named subgraph to the graph, and calls build, if not nil,
+// with a builder of the subgraph. Anonymous subgraphs have empty names, and are
+// named "anon" followed by the first number not making the name of a node or
+// subgraph.
+func (b *Builder) Subgraph(name string, build func(s *Builder)) *Builder {
+	if *b.err != nil {
+		return b
+	}
+	switch {
+	case name == "":
+		for i := len(b.g.SubGraphs.SubGraphs); name == "" || b.g.IsSubGraph(name) || b.g.IsNode(name); i++ {
+			name = fmt.Sprintf("anon%d", i)
+		}
+	case b.g.IsSubGraph(name):
+		return b.fail("subgraph %s: already added", Quote(name))
+	case b.g.IsNode(name):
+		return b.fail("subgraph %s: name already used by a node", Quote(name))
+	}
+	b.g.AddSubGraph(b.parent, name, nil)
+	sub := b.g.SubGraphs.SubGraphs[name]
+	s := &Builder{g: b.g, err: b.err, parent: name}
+	s.target(sub.Attrs, SubGraphContext(name), "subgraph "+Quote(name))
+	if build != nil {
+		build(s)
+	}
+	return b.target(sub.Attrs, SubGraphContext(name), "subgraph "+Quote(name))
+}
+
+// Cluster adds the named cluster subgraph to the graph, as with Subgraph. The
+// name is prefixed by "cluster_" unless already starting with "cluster".
+func (b *Builder) Cluster(name string, build func(c *Builder)) *Builder {
+	if !strings.HasPrefix(name, "cluster") {
+		name = "cluster_" + name
+	}
+	return b.Subgraph(name, build)
+}
+
+// Attr sets the named attribute of the most recently added element, after
+// validating it against the attribute schema.
+func (b *Builder) Attr(name, value string) *Builder {
+	return b.setAttr(b.attrs, b.ctx, b.elem, name, value)
+}
+
+// GraphAttr sets the named attribute of the graph or subgraph being built,
+// after validating it against the attribute schema.
+func (b *Builder) GraphAttr(name, value string) *Builder {
+	if b.parent == b.g.Name {
+		return b.setAttr(b.g.Attrs, ContextGraph, graphElem(b.g.Name), name, value)
+	}
+	return b.setAttr(b.g.SubGraphs.SubGraphs[b.parent].Attrs, SubGraphContext(b.parent), "subgraph "+Quote(b.parent), name, value)
+}
+
+// setAttr sets the named attribute of the given attributes of an element.
+func (b *Builder) setAttr(attrs Attrs, ctx Context, elem, name, value string) *Builder {
+	if *b.err != nil {
+		return b
+	}
+	if msg := lintAttr(ctx, name, value, false); msg != "" {
+		return b.fail("%s: %s", elem, msg)
+	}
+	attrs[name] = value
+	return b
+}
+
+// Build returns the built graph, or the first mistake made while building it.
+// Edges referring to ports not declared by record or HTML-like labels are also
+// reported.
+func (b *Builder) Build() (*Graph, error) {
+	if *b.err != nil {
+		return nil, *b.err
+	}
+	if issues := CheckPorts(b.g); len(issues) > 0 {
+		return nil, fmt.Errorf("%v", issues[0])
+	}
+	linkNodes(b.g)
+	return b.g, nil
+}
+
+// target sets the element to which Attr applies.
+func (b *Builder) target(attrs Attrs, ctx Context, elem string) *Builder {
+	b.attrs, b.ctx, b.elem = attrs, ctx, elem
+	return b
+}
+
+// fail records the given mistake, unless preceded by another.
+func (b *Builder) fail(format string, args ...interface{}) *Builder {
+	if *b.err == nil {
+		*b.err = fmt.Errorf(format, args...)
+	}
+	return b
+}
@@ -0,0 +1,85 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	g, err := NewBuilder().Digraph("G").
+		Attr("rankdir", "LR").
+		Node("a").Attr("shape", "box").Attr("label", "node a").
+		Edge("a", "b").Attr("color", "red").
+		Cluster("c", func(c *Builder) {
+			c.Attr("label", "cluster c").
+				Node("x").
+				Edge("x", "y").
+				GraphAttr("style", "filled")
+		}).
+		Subgraph("", func(s *Builder) {
+			s.Attr("rank", "same").Node("b").Node("z")
+		}).
+		Edge("b", "cluster_c").
+		Node("r").Attr("shape", "record").Attr("label", "<f0> a|<f1> b").
+		PortEdge("r", "f1:s", "a", "n").
+		Build()
+	check(t, err)
+	assert(t, "directed", g.Directed, true)
+	assert(t, "graph attr", g.Attrs["rankdir"], "LR")
+	assert(t, "node label", g.Nodes.Lookup["a"].Attrs["label"], "node a")
+	assert(t, "edge color", g.Edges.SrcToDsts["a"]["b"].Attrs["color"], "red")
+	cluster := g.SubGraphs.SubGraphs["cluster_c"]
+	assert(t, "cluster label", cluster.Attrs["label"], "cluster c")
+	assert(t, "cluster style", cluster.Attrs["style"], "filled")
+	assert(t, "cluster nodes", strings.Join(g.Relations.SortedChildren("cluster_c"), ","), "x,y")
+	assert(t, "anonymous subgraph", g.SubGraphs.SubGraphs["anon1"].Attrs["rank"], "same")
+	assert(t, "port edge", edgeString(g.Edges.SrcToDsts["r"]["a"]), "r:f1:s->a:n")
+	assert(t, "successors", len(g.Nodes.Lookup["a"].Succs), 1)
+
+	// The built graph is written as DOT, and read back.
+	r, err := Read([]byte(g.String()))
+	check(t, err)
+	assert(t, "round trip", Equal(r, g), true)
+}
+
+func TestBuilderMistakes(t *testing.T) {
+	mistakes := map[string]*Builder{
+		"unknown attribute":             NewBuilder().Node("a").Attr("shap", "box"),
+		"invalid value":                 NewBuilder().Node("a").Attr("shape", "hexagonal"),
+		"wrong context":                 NewBuilder().Edge("a", "b").Attr("shape", "box"),
+		"empty node name":               NewBuilder().Node(""),
+		"duplicate subgraph":            NewBuilder().Subgraph("s", nil).Subgraph("s", nil),
+		"subgraph named node":           NewBuilder().Node("s").Subgraph("s", nil),
+		"node named subgraph":           NewBuilder().Subgraph("s", nil).Node("s"),
+		"subgraph port":                 NewBuilder().Subgraph("s", nil).PortEdge("s", "p", "a", ""),
+		"late name":                     NewBuilder().Node("a").Digraph("G"),
+		"undeclared port":               NewBuilder().Node("r").Attr("shape", "record").Attr("label", "<f0> a").PortEdge("r", "f1", "a", ""),
+		"subgraph name":                 NewBuilder().Cluster("c", func(c *Builder) { c.Digraph("G") }),
+		"mistake in subgraph":           NewBuilder().Cluster("c", func(c *Builder) { c.Attr("rank", "middle") }),
+		"later calls no-ops":            NewBuilder().Node("").Node("a").Attr("shape", "box"),
+		"node named anonymous subgraph": NewBuilder().Subgraph("", nil).Node("anon0"),
+	}
+	for desc, b := range mistakes {
+		g, err := b.Build()
+		if err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+		if g != nil {
+			t.Errorf("%s: expected nil graph", desc)
+		}
+	}
+	_, err := NewBuilder().Node("a").Attr("shap", "box").Build()
+	assert(t, "message", err.Error(), `node a: unknown attribute "shap"; did you mean "shape"?`)
+
+	// Anonymous subgraphs are not merged into, or dropped for, elements named
+	// as they would be.
+	g, err := NewBuilder().Subgraph("anon1", nil).Subgraph("", func(s *Builder) { s.Node("a") }).Build()
+	check(t, err)
+	assert(t, "subgraphs", len(g.SubGraphs.SubGraphs), 2)
+	assert(t, "anon1 nodes", len(g.Relations.ParentToChildren["anon1"]), 0)
+	g, err = NewBuilder().Node("anon0").Subgraph("", func(s *Builder) { s.Node("a") }).Build()
+	check(t, err)
+	assert(t, "subgraph", len(g.SubGraphs.SubGraphs), 1)
+	assert(t, "anon0 node", g.IsNode("anon0"), true)
+	assert(t, "a in subgraph", g.Relations.ChildToParents["a"]["anon0"], false)
+}
@@ -0,0 +1,251 @@
+package dot
+
+// This file defines the canonical form of graphs, and semantic equality.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Equal reports whether the graphs are semantically equal; i.e. whether they
+// have the same canonical form, regardless of statement order, attribute
+// order, quoting style and the generated names of anonymous subgraphs.
+func Equal(a, b *Graph) bool {
+	return Canonicalize(a) == Canonicalize(b)
+}
+
+// Canonicalize returns the canonical DOT form of the graph. Graph attributes,
+// subgraphs, nodes, edges and their attributes are written in sorted order,
+// and IDs are quoted only if required. Anonymous subgraphs are named by order
+// of their contents.
+//
+// Subgraphs are nested in the subgraphs containing them. Each node is declared
+// with its attributes in the first subgraph containing it, and otherwise in the
+// root graph. As every node is a member of the root graph, membership of the
+// root graph is not distinguished.
+func Canonicalize(g *Graph) string {
+	c := newCanonWriter(g)
+	buf := &strings.Builder{}
+	if g.Strict {
+		buf.WriteString("strict ")
+	}
+	if g.Directed {
+		buf.WriteString("digraph")
+	} else {
+		buf.WriteString("graph")
+	}
+	if g.Name != "" {
+		fmt.Fprintf(buf, " %s", Quote(g.Name))
+	}
+	buf.WriteString(" {\n")
+	for _, attr := range c.attrs(g.Attrs, g.HTMLAttrs) {
+		fmt.Fprintf(buf, "\t%s;\n", attr)
+	}
+
+	// Subgraphs.
+	c.subGraphs(buf, g.Name, "\t")
+
+	// Nodes.
+	var nodes []string
+	for _, node := range g.Nodes.Nodes {
+		if c.home[node.Name] == g.Name {
+			nodes = append(nodes, c.nodeStmt(node.Name))
+		}
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		fmt.Fprintf(buf, "\t%s;\n", node)
+	}
+
+	// Edges.
+	var edges []string
+	for _, edge := range g.Edges.Edges {
+		edges = append(edges, c.edgeStmt(edge))
+	}
+	sort.Strings(edges)
+	for _, edge := range edges {
+		fmt.Fprintf(buf, "\t%s;\n", edge)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// canonWriter holds the state for writing the canonical form of a graph.
+type canonWriter struct {
+	g *Graph
+	// Subgraphs directly contained in each graph or subgraph, in canonical
+	// order, mapped from its name.
+	subs map[string][]*SubGraph
+	// Canonical name of each subgraph, mapped from its name.
+	subName map[string]string
+	// Canonical name of each node, mapped from its name.
+	nodeName map[string]string
+	// Name of the graph or subgraph declaring each node, mapped from its name.
+	home map[string]string
+}
+
+// newCanonWriter returns a new canonical writer for the given graph.
+func newCanonWriter(g *Graph) *canonWriter {
+	c := &canonWriter{
+		g:        g,
+		subs:     make(map[string][]*SubGraph),
+		subName:  make(map[string]string),
+		nodeName: make(map[string]string),
+		home:     make(map[string]string),
+	}
+	for _, node := range g.Nodes.Nodes {
+		c.nodeName[node.Name] = Quote(node.Name)
+	}
+
+	// Name anonymous subgraphs by order of their contents, including those of
+	// their nested subgraphs.
+	var anon []*SubGraph
+	contents := make(map[string]string)
+	var content func(name string) string
+	content = func(name string) string {
+		if s, ok := contents[name]; ok {
+			return s
+		}
+		sub := g.SubGraphs.SubGraphs[name]
+		var nested []string
+		for _, child := range childSubGraphs(g, name) {
+			if isAnonymous(child) {
+				nested = append(nested, content(child))
+			} else {
+				nested = append(nested, Quote(child))
+			}
+		}
+		sort.Strings(nested)
+		contents[name] = strings.Join(c.attrs(sub.Attrs, sub.HTMLAttrs), ";") + "{" + strings.Join(c.children(name), ";") + "}[" + strings.Join(nested, ";") + "]"
+		return contents[name]
+	}
+	for _, sub := range g.SubGraphs.SubGraphs {
+		if !isAnonymous(sub.Name) {
+			c.subName[sub.Name] = Quote(sub.Name)
+			continue
+		}
+		content(sub.Name)
+		anon = append(anon, sub)
+	}
+	sort.Slice(anon, func(i, j int) bool {
+		return contents[anon[i].Name] < contents[anon[j].Name]
+	})
+	for i, sub := range anon {
+		c.subName[sub.Name] = fmt.Sprintf("anon%d", i)
+	}
+
+	// Nest subgraphs in the subgraphs containing them, and declare nodes in
+	// the first subgraph containing them, and otherwise in the root graph.
+	for _, node := range g.Nodes.Nodes {
+		c.home[node.Name] = g.Name
+	}
+	var nest func(parent string)
+	nest = func(parent string) {
+		var subs []*SubGraph
+		for _, child := range childSubGraphs(g, parent) {
+			subs = append(subs, g.SubGraphs.SubGraphs[child])
+		}
+		sort.Slice(subs, func(i, j int) bool {
+			return c.subName[subs[i].Name] < c.subName[subs[j].Name]
+		})
+		c.subs[parent] = subs
+		for _, sub := range subs {
+			for name := range g.Relations.ParentToChildren[sub.Name] {
+				if c.home[name] == g.Name && g.IsNode(name) {
+					c.home[name] = sub.Name
+				}
+			}
+			nest(sub.Name)
+		}
+	}
+	nest(g.Name)
+	return c
+}
+
+// subGraphs writes the subgraphs directly contained in the named graph or
+// subgraph, and their nested subgraphs, indented by the given prefix.
+func (c *canonWriter) subGraphs(buf *strings.Builder, parent, indent string) {
+	for _, sub := range c.subs[parent] {
+		fmt.Fprintf(buf, "%ssubgraph %s {\n", indent, c.subName[sub.Name])
+		for _, attr := range c.attrs(sub.Attrs, sub.HTMLAttrs) {
+			fmt.Fprintf(buf, "%s\t%s;\n", indent, attr)
+		}
+		for _, name := range c.children(sub.Name) {
+			if c.home[name] == sub.Name {
+				fmt.Fprintf(buf, "%s\t%s;\n", indent, c.nodeStmt(name))
+			} else {
+				fmt.Fprintf(buf, "%s\t%s;\n", indent, c.nodeName[name])
+			}
+		}
+		c.subGraphs(buf, sub.Name, indent+"\t")
+		fmt.Fprintf(buf, "%s}\n", indent)
+	}
+}
+
+// attrs returns the canonical attribute assignments of the given attributes,
+// with the given HTML attributes, in sorted order.
+func (c *canonWriter) attrs(attrs Attrs, html HTMLAttrs) []string {
+	var as []string
+	for name, value := range attrs {
+		as = append(as, Quote(name)+"="+quoteValue(value, html[name]))
+	}
+	sort.Strings(as)
+	return as
+}
+
+// children returns the canonical names of the nodes of the given subgraph, in
+// sorted order.
+func (c *canonWriter) children(sub string) []string {
+	var names []string
+	for name := range c.g.Relations.ParentToChildren[sub] {
+		if canon, ok := c.nodeName[name]; ok {
+			names = append(names, canon)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// attrList returns the canonical attribute list of the given attributes, or
+// the empty string if there are none.
+func (c *canonWriter) attrList(attrs Attrs, html HTMLAttrs) string {
+	as := c.attrs(attrs, html)
+	if len(as) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(as, ", ") + "]"
+}
+
+// nodeStmt returns the canonical node statement of the given node.
+func (c *canonWriter) nodeStmt(name string) string {
+	node := c.g.Nodes.Lookup[name]
+	return c.nodeName[name] + c.attrList(node.Attrs, node.HTMLAttrs)
+}
+
+// edgeStmt returns the canonical edge statement of the given edge.
+func (c *canonWriter) edgeStmt(edge *Edge) string {
+	src := c.endpoint(edge.Src, edge.SrcPort)
+	dst := c.endpoint(edge.Dst, edge.DstPort)
+	op := " -> "
+	if !edge.Dir {
+		op = " -- "
+		// The endpoints of undirected edges are unordered.
+		if src > dst {
+			src, dst = dst, src
+		}
+	}
+	return src + op + dst + c.attrList(edge.Attrs, edge.HTMLAttrs)
+}
+
+// endpoint returns the canonical form of the given edge endpoint.
+func (c *canonWriter) endpoint(name, port string) string {
+	if sub, ok := c.subName[name]; ok && !c.g.IsNode(name) {
+		return "subgraph " + sub + " {" + strings.Join(c.children(name), "; ") + "}"
+	}
+	s, ok := c.nodeName[name]
+	if !ok {
+		s = Quote(name)
+	}
+	return s + quotePort(port)
+}
@@ -0,0 +1,80 @@
+package dot
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	a, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_0 { label="Cluster"; a; b; }
+		a -> b [color=red, style=dashed];
+		b -> {c d};
+		"e f" [shape=box];
+	}`))
+	check(t, err)
+	b, err := Read([]byte(`digraph "G" {
+		"e f" [shape="box"];
+		b -> {d c};
+		subgraph "cluster_0" { b; a; label=Cluster; }
+		a -> b [style="dashed" color="red"];
+		"rankdir"="LR";
+	}`))
+	check(t, err)
+	if !Equal(a, b) {
+		t.Fatalf("graphs not equal:\n%s\n%s", Canonicalize(a), Canonicalize(b))
+	}
+	want := `digraph G {
+	rankdir=LR;
+	subgraph anon0 {
+		c;
+		d;
+	}
+	subgraph cluster_0 {
+		label=Cluster;
+		a;
+		b;
+	}
+	"e f" [shape=box];
+	a -> b [color=red, style=dashed];
+	b -> subgraph anon0 {c; d};
+}
+`
+	assert(t, "canonical form", Canonicalize(a), want)
+
+	// Canonical forms are stable through a round trip.
+	c, err := Read([]byte(Canonicalize(a)))
+	check(t, err)
+	assert(t, "round trip", Canonicalize(c), want)
+
+	b.Nodes.Lookup["a"].Attrs = Attrs{"color": "blue"}
+	assert(t, "equal", Equal(a, b), false)
+}
+
+func TestEqualNesting(t *testing.T) {
+	read := func(src string) *Graph {
+		g, err := Read([]byte(src))
+		check(t, err)
+		return g
+	}
+	nested := read(`digraph G { subgraph cluster_a { y; subgraph cluster_b { x } } }`)
+	flat := read(`digraph G { subgraph cluster_a { y } subgraph cluster_b { x } }`)
+	if Equal(nested, flat) {
+		t.Errorf("nested and flat clusters equal:\n%s", Canonicalize(nested))
+	}
+	want := `digraph G {
+	subgraph cluster_a {
+		y;
+		subgraph cluster_b {
+			x;
+		}
+	}
+}
+`
+	assert(t, "canonical form", Canonicalize(nested), want)
+	assert(t, "round trip", Canonicalize(read(want)), want)
+
+	// Only names generated by the parser are anonymous.
+	x := read(`digraph G { subgraph anonymous_x { a } }`)
+	y := read(`digraph G { subgraph anonymous_y { a } }`)
+	assert(t, "named subgraphs", Equal(x, y), false)
+	assert(t, "anonymous subgraphs", Equal(read(`digraph G { { a } }`), read(`digraph G { subgraph { a } }`)), true)
+}
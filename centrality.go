@@ -0,0 +1,267 @@
+package dot
+
+// This file defines measures of node centrality.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Scores maps nodes to centrality scores.
+type Scores map[*Node]float64
+
+// SetAttr stores each score as the named attribute of its node.
+func (scores Scores) SetAttr(name string) {
+	for node, score := range scores {
+		if node.Attrs == nil {
+			node.Attrs = NewAttrs()
+		}
+		node.Attrs[name] = strconv.FormatFloat(score, 'f', -1, 64)
+	}
+}
+
+// Sorted returns the scored nodes in decreasing order of score, with ties
+// broken by node order.
+func (scores Scores) Sorted() []*Node {
+	nodes := make([]*Node, 0, len(scores))
+	for node := range scores {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		return a.Index < b.Index
+	})
+	return nodes
+}
+
+// scoresOf returns the scores of the nodes of g, indexed by Node.Index.
+func scoresOf(g *Graph, values []float64) Scores {
+	scores := make(Scores, len(values))
+	for _, node := range g.Nodes.Nodes {
+		scores[node] = values[node.Index]
+	}
+	return scores
+}
+
+// DegreeCentrality returns the degree of each node, normalized by the maximum
+// possible degree n-1. Self-loops are counted twice.
+func (g *Graph) DegreeCentrality() Scores {
+	n := len(g.Nodes.Nodes)
+	deg := make([]float64, n)
+	for _, arc := range g.Arcs() {
+		deg[arc.Src.Index]++
+		// The reverse arc of an undirected edge accounts for its destination,
+		// except for self-loops, which have no reverse arc.
+		if arc.Edge.Dir || arc.Src == arc.Dst {
+			deg[arc.Dst.Index]++
+		}
+	}
+	if n > 1 {
+		for i := range deg {
+			deg[i] /= float64(n - 1)
+		}
+	}
+	return scoresOf(g, deg)
+}
+
+// Betweenness returns the betweenness centrality of each node; i.e. the sum
+// over all pairs of other nodes of the fraction of shortest paths between them
+// which pass through the node. Path lengths are measured in number of arcs.
+//
+// The scores are computed using the algorithm described in Brandes. 2001. A
+// faster algorithm for betweenness centrality.
+// https://doi.org/10.1080/0022250X.2001.9990249
+func (g *Graph) Betweenness() Scores {
+	n := len(g.Nodes.Nodes)
+	out := g.outArcs()
+	cb := make([]float64, n)
+	sigma := make([]float64, n)
+	dist := make([]int, n)
+	delta := make([]float64, n)
+	preds := make([][]*Node, n)
+	for _, s := range g.Nodes.Nodes {
+		for i := range sigma {
+			sigma[i] = 0
+			dist[i] = -1
+			delta[i] = 0
+			preds[i] = preds[i][:0]
+		}
+		sigma[s.Index] = 1
+		dist[s.Index] = 0
+		// Nodes in order of non-decreasing distance from s.
+		var order []*Node
+		for queue := []*Node{s}; len(queue) > 0; {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+			for _, arc := range out[v.Index] {
+				w := arc.Dst
+				if dist[w.Index] == -1 {
+					dist[w.Index] = dist[v.Index] + 1
+					queue = append(queue, w)
+				}
+				if dist[w.Index] == dist[v.Index]+1 {
+					sigma[w.Index] += sigma[v.Index]
+					preds[w.Index] = append(preds[w.Index], v)
+				}
+			}
+		}
+		// Accumulate dependencies in order of non-increasing distance.
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range preds[w.Index] {
+				delta[v.Index] += sigma[v.Index] / sigma[w.Index] * (1 + delta[w.Index])
+			}
+			if w != s {
+				cb[w.Index] += delta[w.Index]
+			}
+		}
+	}
+	if !g.Directed {
+		// Each pair of nodes has been accounted for in both directions.
+		for i := range cb {
+			cb[i] /= 2
+		}
+	}
+	return scoresOf(g, cb)
+}
+
+// Closeness returns the closeness centrality of each node, based on the
+// distances to the nodes reachable from it. Path lengths are measured in
+// number of arcs.
+//
+// The closeness of a node which reaches r other nodes at a total distance d is
+// (r/d) * (r/(n-1)), as proposed by Wasserman and Faust, so that nodes in
+// small components are not favoured. Nodes reaching no other nodes score 0.
+func (g *Graph) Closeness() Scores {
+	n := len(g.Nodes.Nodes)
+	out := g.outArcs()
+	closeness := make([]float64, n)
+	dist := make([]int, n)
+	for _, s := range g.Nodes.Nodes {
+		for i := range dist {
+			dist[i] = -1
+		}
+		dist[s.Index] = 0
+		reached, total := 0, 0
+		for queue := []*Node{s}; len(queue) > 0; {
+			v := queue[0]
+			queue = queue[1:]
+			for _, arc := range out[v.Index] {
+				w := arc.Dst
+				if dist[w.Index] == -1 {
+					dist[w.Index] = dist[v.Index] + 1
+					reached++
+					total += dist[w.Index]
+					queue = append(queue, w)
+				}
+			}
+		}
+		if total > 0 {
+			r := float64(reached)
+			closeness[s.Index] = r / float64(total) * r / float64(n-1)
+		}
+	}
+	return scoresOf(g, closeness)
+}
+
+// Convergence parameters of iterative centrality measures.
+const (
+	// Maximum number of iterations.
+	maxIterations = 1000
+	// Tolerance of the sum of absolute score changes between iterations.
+	tolerance = 1e-10
+)
+
+// EigenvectorCentrality returns the eigenvector centrality of each node; i.e.
+// the principal eigenvector of the adjacency matrix, where each node scores
+// in proportion to the scores of its predecessors. The scores have unit
+// Euclidean norm.
+//
+// The eigenvector is computed by power iteration, and an error is returned if
+// it fails to converge.
+func (g *Graph) EigenvectorCentrality() (Scores, error) {
+	n := len(g.Nodes.Nodes)
+	if n == 0 {
+		return Scores{}, nil
+	}
+	arcs := g.Arcs()
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		// Iterate with A^T + I, which has the same eigenvectors as A^T but
+		// converges on periodic graphs.
+		next := make([]float64, n)
+		copy(next, x)
+		for _, arc := range arcs {
+			next[arc.Dst.Index] += x[arc.Src.Index]
+		}
+		norm := 0.0
+		for _, v := range next {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return scoresOf(g, next), nil
+		}
+		diff := 0.0
+		for i := range next {
+			next[i] /= norm
+			diff += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if diff < float64(n)*tolerance {
+			return scoresOf(g, x), nil
+		}
+	}
+	return nil, fmt.Errorf("eigenvector centrality failed to converge in %d iterations", maxIterations)
+}
+
+// PageRank returns the PageRank of each node, with the given damping factor
+// (typically 0.85). The scores sum to 1. The rank of nodes without outgoing
+// arcs is distributed evenly among all nodes.
+func (g *Graph) PageRank(damping float64) Scores {
+	n := len(g.Nodes.Nodes)
+	if n == 0 {
+		return Scores{}
+	}
+	out := g.outArcs()
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		dangling := 0.0
+		for i, arcs := range out {
+			if len(arcs) == 0 {
+				dangling += rank[i]
+			}
+		}
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = (1-damping)/float64(n) + damping*dangling/float64(n)
+		}
+		for i, arcs := range out {
+			share := damping * rank[i] / float64(len(arcs))
+			for _, arc := range arcs {
+				next[arc.Dst.Index] += share
+			}
+		}
+		diff := 0.0
+		for i := range next {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank = next
+		if diff < float64(n)*tolerance {
+			break
+		}
+	}
+	return scoresOf(g, rank)
+}
@@ -0,0 +1,64 @@
+package dot
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(t *testing.T, msg string, got, want float64) {
+	if math.Abs(got-want) > 1e-6 {
+		t.Fatalf("%v %v != %v", msg, got, want)
+	}
+}
+
+func TestCentrality(t *testing.T) {
+	// Star with centre a.
+	g, err := Read([]byte(`graph G { a -- b; a -- c; a -- d; }`))
+	check(t, err)
+	a, b := g.Nodes.Lookup["a"], g.Nodes.Lookup["b"]
+
+	degree := g.DegreeCentrality()
+	approx(t, "degree of a", degree[a], 1)
+	approx(t, "degree of b", degree[b], 1.0/3)
+
+	// Self-loops are counted twice, in undirected and directed graphs.
+	for _, src := range []string{`graph { a -- a; a -- b }`, `digraph { a -> a; a -> b }`} {
+		loop, err := Read([]byte(src))
+		check(t, err)
+		approx(t, "degree of self-loop", loop.DegreeCentrality()[loop.Nodes.Lookup["a"]], 3)
+	}
+
+	betweenness := g.Betweenness()
+	approx(t, "betweenness of a", betweenness[a], 3)
+	approx(t, "betweenness of b", betweenness[b], 0)
+
+	closeness := g.Closeness()
+	approx(t, "closeness of a", closeness[a], 1)
+	approx(t, "closeness of b", closeness[b], 3.0/5)
+
+	eigenvector, err := g.EigenvectorCentrality()
+	check(t, err)
+	approx(t, "eigenvector centrality of a", eigenvector[a], math.Sqrt(0.5))
+	approx(t, "eigenvector centrality of b", eigenvector[b], math.Sqrt(1.0/6))
+
+	pagerank := g.PageRank(0.85)
+	sum := 0.0
+	for _, rank := range pagerank {
+		sum += rank
+	}
+	approx(t, "sum of PageRank", sum, 1)
+	assert(t, "highest PageRank", pagerank.Sorted()[0], a)
+
+	pagerank.SetAttr("rank")
+	if a.Attrs["rank"] == "" {
+		t.Fatalf("missing rank attribute")
+	}
+}
+
+func TestBetweennessDirected(t *testing.T) {
+	g, err := Read([]byte(`digraph G { a -> b -> c; a -> d -> c; }`))
+	check(t, err)
+	betweenness := g.Betweenness()
+	approx(t, "betweenness of b", betweenness[g.Nodes.Lookup["b"]], 0.5)
+	approx(t, "betweenness of a", betweenness[g.Nodes.Lookup["a"]], 0)
+}
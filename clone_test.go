@@ -0,0 +1,37 @@
+package dot
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a [label=entry];
+		subgraph cluster_0 { b; c; }
+		a -> b [color=red];
+		a -> c;
+		b -> d;
+		c -> d;
+	}`))
+	check(t, err)
+	c := g.Clone()
+	assert(t, "string", c.String(), g.String())
+	a, d := c.Nodes.Lookup["a"], c.Nodes.Lookup["d"]
+	assert(t, "successors", nodeNames(a.Succs), "[b c]")
+	assert(t, "successor identity", a.Succs[0], c.Nodes.Lookup["b"])
+	assert(t, "immediate dominator", d.Idom(), a)
+	assert(t, "dominates", a.Dominates(d), true)
+	assert(t, "edge identity", c.Edges.SrcToDsts["a"]["b"], c.Edges.Edges[0])
+	assert(t, "subgraph parent", c.SubGraphs.SubGraphs["cluster_0"].Parent, "G")
+
+	// Modifications of the clone do not affect the original.
+	c.Edges.SrcToDsts["a"]["b"].Attrs["color"] = "blue"
+	c.Nodes.Lookup["d"].Attrs = Attrs{"shape": "box"}
+	c.SubGraphs.SubGraphs["cluster_0"].Attrs["label"] = "x"
+	b, cc := c.Nodes.Lookup["b"], c.Nodes.Lookup["c"]
+	check(t, c.Replace([]*Node{b, cc}, "bc", b, cc))
+	assert(t, "original edge attribute", g.Edges.SrcToDsts["a"]["b"].Attrs["color"], "red")
+	assert(t, "original node attribute", g.Nodes.Lookup["d"].Attrs["shape"], "")
+	assert(t, "original subgraph attribute", g.SubGraphs.SubGraphs["cluster_0"].Attrs["label"], "")
+	assert(t, "original nodes", nodeNames(g.Nodes.Nodes), "[a b c d]")
+	assert(t, "original successors", nodeNames(g.Nodes.Lookup["a"].Succs), "[b c]")
+	assert(t, "original immediate dominator", g.Nodes.Lookup["d"].Idom(), g.Nodes.Lookup["a"])
+}
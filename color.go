@@ -0,0 +1,341 @@
+package dot
+
+// This file defines the parsing and formatting of Graphviz colours, as
+// documented at https://graphviz.org/docs/attr-types/color/
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseColor parses the given Graphviz colour. A colour
+// is either an RGB(A) value "#rrggbb[aa]", an HSV(A) value "H,S,V[,A]" with
+// components in [0, 1], or a case-insensitive colour name. Colour names are
+// resolved using the given colour scheme, or the x11 scheme if empty, unless
+// prefixed by their scheme as in "/accent3/1". Names not present in a brewer
+// or svg scheme are resolved using the x11 scheme. The names "transparent" and
+// "none" denote a fully transparent colour.
+func ParseColor(s, scheme string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return color.RGBA{}, fmt.Errorf("empty color")
+	case s[0] == '#':
+		return parseRGBA(s)
+	case s[0] == '.' || isDigit(rune(s[0])):
+		if strings.ContainsAny(s, ", ") {
+			return parseHSVA(s)
+		}
+	case s[0] == '/':
+		// "/scheme/name" or "//name" for the default scheme.
+		i := strings.Index(s[1:], "/")
+		if i == -1 {
+			return color.RGBA{}, fmt.Errorf("invalid color %q; expected /scheme/name", s)
+		}
+		if name := s[1 : i+1]; name != "" {
+			scheme = name
+		}
+		s = s[i+2:]
+	}
+	return lookupColor(s, scheme)
+}
+
+// transparent is the colour of the names "transparent" and "none", which are
+// valid in every colour scheme.
+var transparent = color.RGBA{0xff, 0xff, 0xfe, 0x00}
+
+// lookupColor returns the named colour of the given colour scheme.
+func lookupColor(name, scheme string) (color.RGBA, error) {
+	name = strings.ToLower(name)
+	scheme = strings.ToLower(scheme)
+	if name == "transparent" || name == "none" {
+		return transparent, nil
+	}
+	switch scheme {
+	case "", "x11":
+		if rgb, ok := x11Colors[name]; ok {
+			return rgbColor(rgb), nil
+		}
+		return color.RGBA{}, fmt.Errorf("unknown color %q", name)
+	case "svg":
+		if rgb, ok := svgColors[name]; ok {
+			return rgbColor(rgb), nil
+		}
+	default:
+		colors, ok := brewerSchemes[scheme]
+		if !ok {
+			return color.RGBA{}, fmt.Errorf("unknown color scheme %q", scheme)
+		}
+		if i, err := strconv.Atoi(name); err == nil {
+			if i < 1 || 6*i > len(colors) {
+				return color.RGBA{}, fmt.Errorf("color %d out of range of color scheme %q with %d colors", i, scheme, len(colors)/6)
+			}
+			rgb, _ := strconv.ParseUint(colors[6*(i-1):6*i], 16, 32)
+			return rgbColor(uint32(rgb)), nil
+		}
+	}
+	return lookupColor(name, "x11")
+}
+
+// rgbColor returns the opaque colour of the given 0xRRGGBB value.
+func rgbColor(rgb uint32) color.RGBA {
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
+
+// parseRGBA parses a colour of the form "#rrggbb" or "#rrggbbaa".
+func parseRGBA(s string) (color.RGBA, error) {
+	hex := s[1:]
+	if len(hex) != 6 && len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q; expected #rrggbb or #rrggbbaa", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q; expected #rrggbb or #rrggbbaa", s)
+	}
+	if len(hex) == 6 {
+		return rgbColor(uint32(v)), nil
+	}
+	c := rgbColor(uint32(v >> 8))
+	c.A = uint8(v)
+	return c, nil
+}
+
+// parseHSVA parses a colour of the form "H,S,V" or "H,S,V,A", where the
+// components are separated by commas or whitespace.
+func parseHSVA(s string) (color.RGBA, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) != 3 && len(fields) != 4 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q; expected H,S,V or H,S,V,A", s)
+	}
+	var vs []float64
+	for _, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil || v < 0 || v > 1 {
+			return color.RGBA{}, fmt.Errorf("invalid color %q; expected components in [0, 1]", s)
+		}
+		vs = append(vs, v)
+	}
+	c := hsvColor(vs[0], vs[1], vs[2])
+	if len(vs) == 4 {
+		c.A = uint8(math.Round(vs[3] * 255))
+	}
+	return c, nil
+}
+
+// hsvColor returns the opaque colour of the given hue, saturation and value,
+// each in [0, 1].
+func hsvColor(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 1) * 6
+	i := math.Floor(h)
+	f := h - i
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+	var r, g, b float64
+	switch int(i) {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return color.RGBA{
+		R: uint8(math.Round(r * 255)),
+		G: uint8(math.Round(g * 255)),
+		B: uint8(math.Round(b * 255)),
+		A: 0xFF,
+	}
+}
+
+// FormatColor returns the given colour as an RGB value "#rrggbb", or an RGBA
+// value "#rrggbbaa" if not opaque.
+func FormatColor(c color.RGBA) string {
+	if c.A == 0xFF {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// WeightedColor is a colour of a colour list, e.g. "red;0.3:blue".
+type WeightedColor struct {
+	Color color.RGBA
+	// Fraction of the area covered by the colour, or 0 if unspecified.
+	Weight float64
+}
+
+// ParseColorList parses the given Graphviz colour list.
+// Colours are separated by colons, and may be followed by a semicolon and a
+// weight in [0, 1]. The sum of the weights must not exceed 1. Colour names are
+// resolved using the given colour scheme, as with ParseColor.
+func ParseColorList(s, scheme string) ([]WeightedColor, error) {
+	var colors []WeightedColor
+	sum := 0.0
+	for _, part := range strings.Split(s, ":") {
+		var c WeightedColor
+		if i := strings.Index(part, ";"); i != -1 {
+			w, err := strconv.ParseFloat(part[i+1:], 64)
+			if err != nil || w < 0 || w > 1 {
+				return nil, fmt.Errorf("invalid weight %q of color %q; expected number in [0, 1]", part[i+1:], part[:i])
+			}
+			c.Weight = w
+			sum += w
+			part = part[:i]
+		}
+		var err error
+		if c.Color, err = ParseColor(part, scheme); err != nil {
+			return nil, err
+		}
+		colors = append(colors, c)
+	}
+	if sum > 1+1e-9 {
+		return nil, fmt.Errorf("invalid color list %q; weights sum to %v, which exceeds 1", s, sum)
+	}
+	return colors, nil
+}
+
+// FormatColorList returns the given colours as a Graphviz colour list.
+func FormatColorList(colors []WeightedColor) string {
+	var parts []string
+	for _, c := range colors {
+		part := FormatColor(c.Color)
+		if c.Weight != 0 {
+			part += ";" + strconv.FormatFloat(c.Weight, 'f', -1, 64)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ":")
+}
+
+// isColorAttr reports whether the named attribute has a colour value.
+func isColorAttr(name string) bool {
+	spec, ok := LookupAttr(name)
+	if !ok {
+		return false
+	}
+	for _, t := range spec.Types {
+		if t == TypeColor || t == TypeColorList {
+			return true
+		}
+	}
+	return false
+}
+
+// colorsAttr returns the colours of the named colour attribute, or of its
+// default value if absent, resolved using the colorscheme attribute.
+func colorsAttr(attrs Attrs, name string) ([]WeightedColor, error) {
+	value := attrValue(attrs, name)
+	if value == "" {
+		return nil, nil
+	}
+	colors, err := ParseColorList(value, attrValue(attrs, "colorscheme"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q of attribute %q; %v", value, name, err)
+	}
+	return colors, nil
+}
+
+// Colors returns the colours of the named colour attribute of the node, e.g.
+// "fillcolor", resolved using its colour scheme.
+func (n *Node) Colors(name string) ([]WeightedColor, error) {
+	return colorsAttr(n.Attrs, name)
+}
+
+// SetColors sets the named colour attribute of the node to the given colours.
+func (n *Node) SetColors(name string, colors ...WeightedColor) error {
+	return n.SetAttr(name, FormatColorList(colors))
+}
+
+// RGBA returns the first colour of the named colour attribute of the node.
+func (n *Node) RGBA(name string) (color.RGBA, error) {
+	return firstColor(n.Colors(name))
+}
+
+// SetRGBA sets the named colour attribute of the node to the given colour.
+func (n *Node) SetRGBA(name string, c color.RGBA) error {
+	return n.SetAttr(name, FormatColor(c))
+}
+
+// Colors returns the colours of the named colour attribute of the edge, e.g.
+// "color", resolved using its colour scheme.
+func (e *Edge) Colors(name string) ([]WeightedColor, error) {
+	return colorsAttr(e.Attrs, name)
+}
+
+// SetColors sets the named colour attribute of the edge to the given colours,
+// e.g. to draw parallel edges in several colours.
+func (e *Edge) SetColors(name string, colors ...WeightedColor) error {
+	return e.SetAttr(name, FormatColorList(colors))
+}
+
+// RGBA returns the first colour of the named colour attribute of the edge.
+func (e *Edge) RGBA(name string) (color.RGBA, error) {
+	return firstColor(e.Colors(name))
+}
+
+// SetRGBA sets the named colour attribute of the edge to the given colour.
+func (e *Edge) SetRGBA(name string, c color.RGBA) error {
+	return e.SetAttr(name, FormatColor(c))
+}
+
+// firstColor returns the first of the given colours, or transparent black if
+// none.
+func firstColor(colors []WeightedColor, err error) (color.RGBA, error) {
+	if err != nil || len(colors) == 0 {
+		return color.RGBA{}, err
+	}
+	return colors[0].Color, nil
+}
+
+// MapColors replaces each colour of the colour attributes of the graph, its
+// subgraphs, nodes and edges by the result of f, e.g. to apply a theme. Colour
+// names are resolved using the colour scheme of each element, and replaced by
+// RGB(A) values. Weights of colour lists are retained.
+func MapColors(g *Graph, f func(c color.RGBA) color.RGBA) error {
+	mapAttrs := func(attrs Attrs) error {
+		for _, name := range attrs.SortedNames() {
+			if !isColorAttr(name) {
+				continue
+			}
+			colors, err := colorsAttr(attrs, name)
+			if err != nil {
+				return err
+			}
+			for i := range colors {
+				colors[i].Color = f(colors[i].Color)
+			}
+			attrs[name] = FormatColorList(colors)
+		}
+		return nil
+	}
+	if err := mapAttrs(g.Attrs); err != nil {
+		return fmt.Errorf("graph %q: %v", g.Name, err)
+	}
+	for _, sub := range g.SubGraphs.Sorted() {
+		if err := mapAttrs(sub.Attrs); err != nil {
+			return fmt.Errorf("subgraph %q: %v", sub.Name, err)
+		}
+	}
+	for _, node := range g.Nodes.Nodes {
+		if err := mapAttrs(node.Attrs); err != nil {
+			return fmt.Errorf("node %q: %v", node.Name, err)
+		}
+	}
+	for _, edge := range g.Edges.Edges {
+		if err := mapAttrs(edge.Attrs); err != nil {
+			return fmt.Errorf("edge %s: %v", edgeString(edge), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package dot
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		s      string
+		scheme string
+		want   color.RGBA
+	}{
+		{"#ff8000", "", color.RGBA{0xff, 0x80, 0x00, 0xff}},
+		{"#FF800080", "", color.RGBA{0xff, 0x80, 0x00, 0x80}},
+		{"red", "", color.RGBA{0xff, 0x00, 0x00, 0xff}},
+		{"DarkSlateGray4", "", color.RGBA{0x52, 0x8b, 0x8b, 0xff}},
+		// The x11 and svg schemes differ on green.
+		{"green", "", color.RGBA{0x00, 0xff, 0x00, 0xff}},
+		{"green", "svg", color.RGBA{0x00, 0x80, 0x00, 0xff}},
+		{"/svg/green", "", color.RGBA{0x00, 0x80, 0x00, 0xff}},
+		{"//green", "svg", color.RGBA{0x00, 0x80, 0x00, 0xff}},
+		{"/accent3/1", "", color.RGBA{0x7f, 0xc9, 0x7f, 0xff}},
+		{"3", "blues3", color.RGBA{0x31, 0x82, 0xbd, 0xff}},
+		// Names not present in a brewer scheme are resolved using x11.
+		{"white", "blues3", color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{"0.000 1.000 1.000", "", color.RGBA{0xff, 0x00, 0x00, 0xff}},
+		{"0.333,1,0.5", "", color.RGBA{0x00, 0x80, 0x00, 0xff}},
+		{".5 0 1 .5", "", color.RGBA{0xff, 0xff, 0xff, 0x80}},
+		{"transparent", "", color.RGBA{0xff, 0xff, 0xfe, 0x00}},
+		{"None", "blues3", color.RGBA{0xff, 0xff, 0xfe, 0x00}},
+	}
+	for _, test := range tests {
+		got, err := ParseColor(test.s, test.scheme)
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		assert(t, test.s, got, test.want)
+	}
+	for _, s := range []string{"", "#ff80", "#gg0000", "bleu", "/nosuch/1", "/accent3/4", "0.5 1.5 1"} {
+		if _, err := ParseColor(s, ""); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func TestColorList(t *testing.T) {
+	colors, err := ParseColorList("red;0.3:blue", "")
+	check(t, err)
+	assert(t, "colors", len(colors), 2)
+	assert(t, "first weight", colors[0].Weight, 0.3)
+	assert(t, "second color", colors[1].Color, color.RGBA{0x00, 0x00, 0xff, 0xff})
+	assert(t, "format", FormatColorList(colors), "#ff0000;0.3:#0000ff")
+	if _, err := ParseColorList("red;0.7:blue;0.5", ""); err == nil {
+		t.Errorf("expected error for weights exceeding 1")
+	}
+	if _, err := ParseColorList("red;x", ""); err == nil {
+		t.Errorf("expected error for invalid weight")
+	}
+}
+
+func TestColorAttrs(t *testing.T) {
+	g, err := Read([]byte(`digraph {
+		node [colorscheme=set13];
+		bgcolor=lightgrey;
+		a [fillcolor=2, style=filled];
+		b;
+		a -> b [color="red:green;0.5"];
+	}`))
+	check(t, err)
+	a, b := g.Nodes.Lookup["a"], g.Nodes.Lookup["b"]
+	fill, err := a.RGBA("fillcolor")
+	check(t, err)
+	assert(t, "brewer fill", fill, color.RGBA{0x37, 0x7e, 0xb8, 0xff})
+	c, err := b.RGBA("color")
+	check(t, err)
+	assert(t, "default color", c, color.RGBA{0x00, 0x00, 0x00, 0xff})
+
+	check(t, b.SetRGBA("fillcolor", color.RGBA{0x10, 0x20, 0x30, 0x80}))
+	assert(t, "set fillcolor", b.Attrs["fillcolor"], "#10203080")
+	edge := g.Edges.Edges[0]
+	check(t, edge.SetColors("color", WeightedColor{Color: color.RGBA{0, 0, 0xff, 0xff}, Weight: 0.25}, WeightedColor{Color: color.RGBA{0xff, 0xff, 0xff, 0xff}}))
+	assert(t, "set colors", edge.Attrs["color"], "#0000ff;0.25:#ffffff")
+
+	// Invert all colours.
+	check(t, edge.SetColors("color", WeightedColor{Color: color.RGBA{0xff, 0, 0, 0xff}}, WeightedColor{Color: color.RGBA{0, 0xff, 0, 0xff}, Weight: 0.5}))
+	check(t, MapColors(g, func(c color.RGBA) color.RGBA {
+		return color.RGBA{^c.R, ^c.G, ^c.B, c.A}
+	}))
+	assert(t, "graph bgcolor", g.Attrs["bgcolor"], "#2c2c2c")
+	assert(t, "node fillcolor", a.Attrs["fillcolor"], "#c88147")
+	assert(t, "edge color", edge.Attrs["color"], "#00ffff:#ff00ff;0.5")
+	assert(t, "colorscheme unchanged", a.Attrs["colorscheme"], "set13")
+}
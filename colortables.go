@@ -0,0 +1,1091 @@
+package dot
+
+// This file contains the colour tables of the x11, svg and brewer colour
+// schemes. The x11 colours are taken from the X.Org rgb.txt file, the svg
+// colours from the SVG 1.1 specification, and the brewer colours from
+// ColorBrewer (http://colorbrewer2.org) by Cynthia A. Brewer.
+
+// x11Colors maps from colour names of the x11 scheme to their 0xRRGGBB values.
+var x11Colors = map[string]uint32{
+	"aliceblue":            0xf0f8ff,
+	"antiquewhite":         0xfaebd7,
+	"antiquewhite1":        0xffefdb,
+	"antiquewhite2":        0xeedfcc,
+	"antiquewhite3":        0xcdc0b0,
+	"antiquewhite4":        0x8b8378,
+	"aquamarine":           0x7fffd4,
+	"aquamarine1":          0x7fffd4,
+	"aquamarine2":          0x76eec6,
+	"aquamarine3":          0x66cdaa,
+	"aquamarine4":          0x458b74,
+	"azure":                0xf0ffff,
+	"azure1":               0xf0ffff,
+	"azure2":               0xe0eeee,
+	"azure3":               0xc1cdcd,
+	"azure4":               0x838b8b,
+	"beige":                0xf5f5dc,
+	"bisque":               0xffe4c4,
+	"bisque1":              0xffe4c4,
+	"bisque2":              0xeed5b7,
+	"bisque3":              0xcdb79e,
+	"bisque4":              0x8b7d6b,
+	"black":                0x000000,
+	"blanchedalmond":       0xffebcd,
+	"blue":                 0x0000ff,
+	"blue1":                0x0000ff,
+	"blue2":                0x0000ee,
+	"blue3":                0x0000cd,
+	"blue4":                0x00008b,
+	"blueviolet":           0x8a2be2,
+	"brown":                0xa52a2a,
+	"brown1":               0xff4040,
+	"brown2":               0xee3b3b,
+	"brown3":               0xcd3333,
+	"brown4":               0x8b2323,
+	"burlywood":            0xdeb887,
+	"burlywood1":           0xffd39b,
+	"burlywood2":           0xeec591,
+	"burlywood3":           0xcdaa7d,
+	"burlywood4":           0x8b7355,
+	"cadetblue":            0x5f9ea0,
+	"cadetblue1":           0x98f5ff,
+	"cadetblue2":           0x8ee5ee,
+	"cadetblue3":           0x7ac5cd,
+	"cadetblue4":           0x53868b,
+	"chartreuse":           0x7fff00,
+	"chartreuse1":          0x7fff00,
+	"chartreuse2":          0x76ee00,
+	"chartreuse3":          0x66cd00,
+	"chartreuse4":          0x458b00,
+	"chocolate":            0xd2691e,
+	"chocolate1":           0xff7f24,
+	"chocolate2":           0xee7621,
+	"chocolate3":           0xcd661d,
+	"chocolate4":           0x8b4513,
+	"coral":                0xff7f50,
+	"coral1":               0xff7256,
+	"coral2":               0xee6a50,
+	"coral3":               0xcd5b45,
+	"coral4":               0x8b3e2f,
+	"cornflowerblue":       0x6495ed,
+	"cornsilk":             0xfff8dc,
+	"cornsilk1":            0xfff8dc,
+	"cornsilk2":            0xeee8cd,
+	"cornsilk3":            0xcdc8b1,
+	"cornsilk4":            0x8b8878,
+	"crimson":              0xdc143c,
+	"cyan":                 0x00ffff,
+	"cyan1":                0x00ffff,
+	"cyan2":                0x00eeee,
+	"cyan3":                0x00cdcd,
+	"cyan4":                0x008b8b,
+	"darkblue":             0x00008b,
+	"darkcyan":             0x008b8b,
+	"darkgoldenrod":        0xb8860b,
+	"darkgoldenrod1":       0xffb90f,
+	"darkgoldenrod2":       0xeead0e,
+	"darkgoldenrod3":       0xcd950c,
+	"darkgoldenrod4":       0x8b6508,
+	"darkgray":             0xa9a9a9,
+	"darkgreen":            0x006400,
+	"darkgrey":             0xa9a9a9,
+	"darkkhaki":            0xbdb76b,
+	"darkmagenta":          0x8b008b,
+	"darkolivegreen":       0x556b2f,
+	"darkolivegreen1":      0xcaff70,
+	"darkolivegreen2":      0xbcee68,
+	"darkolivegreen3":      0xa2cd5a,
+	"darkolivegreen4":      0x6e8b3d,
+	"darkorange":           0xff8c00,
+	"darkorange1":          0xff7f00,
+	"darkorange2":          0xee7600,
+	"darkorange3":          0xcd6600,
+	"darkorange4":          0x8b4500,
+	"darkorchid":           0x9932cc,
+	"darkorchid1":          0xbf3eff,
+	"darkorchid2":          0xb23aee,
+	"darkorchid3":          0x9a32cd,
+	"darkorchid4":          0x68228b,
+	"darkred":              0x8b0000,
+	"darksalmon":           0xe9967a,
+	"darkseagreen":         0x8fbc8f,
+	"darkseagreen1":        0xc1ffc1,
+	"darkseagreen2":        0xb4eeb4,
+	"darkseagreen3":        0x9bcd9b,
+	"darkseagreen4":        0x698b69,
+	"darkslateblue":        0x483d8b,
+	"darkslategray":        0x2f4f4f,
+	"darkslategray1":       0x97ffff,
+	"darkslategray2":       0x8deeee,
+	"darkslategray3":       0x79cdcd,
+	"darkslategray4":       0x528b8b,
+	"darkslategrey":        0x2f4f4f,
+	"darkturquoise":        0x00ced1,
+	"darkviolet":           0x9400d3,
+	"deeppink":             0xff1493,
+	"deeppink1":            0xff1493,
+	"deeppink2":            0xee1289,
+	"deeppink3":            0xcd1076,
+	"deeppink4":            0x8b0a50,
+	"deepskyblue":          0x00bfff,
+	"deepskyblue1":         0x00bfff,
+	"deepskyblue2":         0x00b2ee,
+	"deepskyblue3":         0x009acd,
+	"deepskyblue4":         0x00688b,
+	"dimgray":              0x696969,
+	"dimgrey":              0x696969,
+	"dodgerblue":           0x1e90ff,
+	"dodgerblue1":          0x1e90ff,
+	"dodgerblue2":          0x1c86ee,
+	"dodgerblue3":          0x1874cd,
+	"dodgerblue4":          0x104e8b,
+	"firebrick":            0xb22222,
+	"firebrick1":           0xff3030,
+	"firebrick2":           0xee2c2c,
+	"firebrick3":           0xcd2626,
+	"firebrick4":           0x8b1a1a,
+	"floralwhite":          0xfffaf0,
+	"forestgreen":          0x228b22,
+	"gainsboro":            0xdcdcdc,
+	"ghostwhite":           0xf8f8ff,
+	"gold":                 0xffd700,
+	"gold1":                0xffd700,
+	"gold2":                0xeec900,
+	"gold3":                0xcdad00,
+	"gold4":                0x8b7500,
+	"goldenrod":            0xdaa520,
+	"goldenrod1":           0xffc125,
+	"goldenrod2":           0xeeb422,
+	"goldenrod3":           0xcd9b1d,
+	"goldenrod4":           0x8b6914,
+	"gray":                 0xc0c0c0,
+	"gray0":                0x000000,
+	"gray1":                0x030303,
+	"gray10":               0x1a1a1a,
+	"gray100":              0xffffff,
+	"gray11":               0x1c1c1c,
+	"gray12":               0x1f1f1f,
+	"gray13":               0x212121,
+	"gray14":               0x242424,
+	"gray15":               0x262626,
+	"gray16":               0x292929,
+	"gray17":               0x2b2b2b,
+	"gray18":               0x2e2e2e,
+	"gray19":               0x303030,
+	"gray2":                0x050505,
+	"gray20":               0x333333,
+	"gray21":               0x363636,
+	"gray22":               0x383838,
+	"gray23":               0x3b3b3b,
+	"gray24":               0x3d3d3d,
+	"gray25":               0x404040,
+	"gray26":               0x424242,
+	"gray27":               0x454545,
+	"gray28":               0x474747,
+	"gray29":               0x4a4a4a,
+	"gray3":                0x080808,
+	"gray30":               0x4d4d4d,
+	"gray31":               0x4f4f4f,
+	"gray32":               0x525252,
+	"gray33":               0x545454,
+	"gray34":               0x575757,
+	"gray35":               0x595959,
+	"gray36":               0x5c5c5c,
+	"gray37":               0x5e5e5e,
+	"gray38":               0x616161,
+	"gray39":               0x636363,
+	"gray4":                0x0a0a0a,
+	"gray40":               0x666666,
+	"gray41":               0x696969,
+	"gray42":               0x6b6b6b,
+	"gray43":               0x6e6e6e,
+	"gray44":               0x707070,
+	"gray45":               0x737373,
+	"gray46":               0x757575,
+	"gray47":               0x787878,
+	"gray48":               0x7a7a7a,
+	"gray49":               0x7d7d7d,
+	"gray5":                0x0d0d0d,
+	"gray50":               0x7f7f7f,
+	"gray51":               0x828282,
+	"gray52":               0x858585,
+	"gray53":               0x878787,
+	"gray54":               0x8a8a8a,
+	"gray55":               0x8c8c8c,
+	"gray56":               0x8f8f8f,
+	"gray57":               0x919191,
+	"gray58":               0x949494,
+	"gray59":               0x969696,
+	"gray6":                0x0f0f0f,
+	"gray60":               0x999999,
+	"gray61":               0x9c9c9c,
+	"gray62":               0x9e9e9e,
+	"gray63":               0xa1a1a1,
+	"gray64":               0xa3a3a3,
+	"gray65":               0xa6a6a6,
+	"gray66":               0xa8a8a8,
+	"gray67":               0xababab,
+	"gray68":               0xadadad,
+	"gray69":               0xb0b0b0,
+	"gray7":                0x121212,
+	"gray70":               0xb3b3b3,
+	"gray71":               0xb5b5b5,
+	"gray72":               0xb8b8b8,
+	"gray73":               0xbababa,
+	"gray74":               0xbdbdbd,
+	"gray75":               0xbfbfbf,
+	"gray76":               0xc2c2c2,
+	"gray77":               0xc4c4c4,
+	"gray78":               0xc7c7c7,
+	"gray79":               0xc9c9c9,
+	"gray8":                0x141414,
+	"gray80":               0xcccccc,
+	"gray81":               0xcfcfcf,
+	"gray82":               0xd1d1d1,
+	"gray83":               0xd4d4d4,
+	"gray84":               0xd6d6d6,
+	"gray85":               0xd9d9d9,
+	"gray86":               0xdbdbdb,
+	"gray87":               0xdedede,
+	"gray88":               0xe0e0e0,
+	"gray89":               0xe3e3e3,
+	"gray9":                0x171717,
+	"gray90":               0xe5e5e5,
+	"gray91":               0xe8e8e8,
+	"gray92":               0xebebeb,
+	"gray93":               0xededed,
+	"gray94":               0xf0f0f0,
+	"gray95":               0xf2f2f2,
+	"gray96":               0xf5f5f5,
+	"gray97":               0xf7f7f7,
+	"gray98":               0xfafafa,
+	"gray99":               0xfcfcfc,
+	"green":                0x00ff00,
+	"green1":               0x00ff00,
+	"green2":               0x00ee00,
+	"green3":               0x00cd00,
+	"green4":               0x008b00,
+	"greenyellow":          0xadff2f,
+	"grey":                 0xc0c0c0,
+	"grey0":                0x000000,
+	"grey1":                0x030303,
+	"grey10":               0x1a1a1a,
+	"grey100":              0xffffff,
+	"grey11":               0x1c1c1c,
+	"grey12":               0x1f1f1f,
+	"grey13":               0x212121,
+	"grey14":               0x242424,
+	"grey15":               0x262626,
+	"grey16":               0x292929,
+	"grey17":               0x2b2b2b,
+	"grey18":               0x2e2e2e,
+	"grey19":               0x303030,
+	"grey2":                0x050505,
+	"grey20":               0x333333,
+	"grey21":               0x363636,
+	"grey22":               0x383838,
+	"grey23":               0x3b3b3b,
+	"grey24":               0x3d3d3d,
+	"grey25":               0x404040,
+	"grey26":               0x424242,
+	"grey27":               0x454545,
+	"grey28":               0x474747,
+	"grey29":               0x4a4a4a,
+	"grey3":                0x080808,
+	"grey30":               0x4d4d4d,
+	"grey31":               0x4f4f4f,
+	"grey32":               0x525252,
+	"grey33":               0x545454,
+	"grey34":               0x575757,
+	"grey35":               0x595959,
+	"grey36":               0x5c5c5c,
+	"grey37":               0x5e5e5e,
+	"grey38":               0x616161,
+	"grey39":               0x636363,
+	"grey4":                0x0a0a0a,
+	"grey40":               0x666666,
+	"grey41":               0x696969,
+	"grey42":               0x6b6b6b,
+	"grey43":               0x6e6e6e,
+	"grey44":               0x707070,
+	"grey45":               0x737373,
+	"grey46":               0x757575,
+	"grey47":               0x787878,
+	"grey48":               0x7a7a7a,
+	"grey49":               0x7d7d7d,
+	"grey5":                0x0d0d0d,
+	"grey50":               0x7f7f7f,
+	"grey51":               0x828282,
+	"grey52":               0x858585,
+	"grey53":               0x878787,
+	"grey54":               0x8a8a8a,
+	"grey55":               0x8c8c8c,
+	"grey56":               0x8f8f8f,
+	"grey57":               0x919191,
+	"grey58":               0x949494,
+	"grey59":               0x969696,
+	"grey6":                0x0f0f0f,
+	"grey60":               0x999999,
+	"grey61":               0x9c9c9c,
+	"grey62":               0x9e9e9e,
+	"grey63":               0xa1a1a1,
+	"grey64":               0xa3a3a3,
+	"grey65":               0xa6a6a6,
+	"grey66":               0xa8a8a8,
+	"grey67":               0xababab,
+	"grey68":               0xadadad,
+	"grey69":               0xb0b0b0,
+	"grey7":                0x121212,
+	"grey70":               0xb3b3b3,
+	"grey71":               0xb5b5b5,
+	"grey72":               0xb8b8b8,
+	"grey73":               0xbababa,
+	"grey74":               0xbdbdbd,
+	"grey75":               0xbfbfbf,
+	"grey76":               0xc2c2c2,
+	"grey77":               0xc4c4c4,
+	"grey78":               0xc7c7c7,
+	"grey79":               0xc9c9c9,
+	"grey8":                0x141414,
+	"grey80":               0xcccccc,
+	"grey81":               0xcfcfcf,
+	"grey82":               0xd1d1d1,
+	"grey83":               0xd4d4d4,
+	"grey84":               0xd6d6d6,
+	"grey85":               0xd9d9d9,
+	"grey86":               0xdbdbdb,
+	"grey87":               0xdedede,
+	"grey88":               0xe0e0e0,
+	"grey89":               0xe3e3e3,
+	"grey9":                0x171717,
+	"grey90":               0xe5e5e5,
+	"grey91":               0xe8e8e8,
+	"grey92":               0xebebeb,
+	"grey93":               0xededed,
+	"grey94":               0xf0f0f0,
+	"grey95":               0xf2f2f2,
+	"grey96":               0xf5f5f5,
+	"grey97":               0xf7f7f7,
+	"grey98":               0xfafafa,
+	"grey99":               0xfcfcfc,
+	"honeydew":             0xf0fff0,
+	"honeydew1":            0xf0fff0,
+	"honeydew2":            0xe0eee0,
+	"honeydew3":            0xc1cdc1,
+	"honeydew4":            0x838b83,
+	"hotpink":              0xff69b4,
+	"hotpink1":             0xff6eb4,
+	"hotpink2":             0xee6aa7,
+	"hotpink3":             0xcd6090,
+	"hotpink4":             0x8b3a62,
+	"indianred":            0xcd5c5c,
+	"indianred1":           0xff6a6a,
+	"indianred2":           0xee6363,
+	"indianred3":           0xcd5555,
+	"indianred4":           0x8b3a3a,
+	"indigo":               0x4b0082,
+	"ivory":                0xfffff0,
+	"ivory1":               0xfffff0,
+	"ivory2":               0xeeeee0,
+	"ivory3":               0xcdcdc1,
+	"ivory4":               0x8b8b83,
+	"khaki":                0xf0e68c,
+	"khaki1":               0xfff68f,
+	"khaki2":               0xeee685,
+	"khaki3":               0xcdc673,
+	"khaki4":               0x8b864e,
+	"lavender":             0xe6e6fa,
+	"lavenderblush":        0xfff0f5,
+	"lavenderblush1":       0xfff0f5,
+	"lavenderblush2":       0xeee0e5,
+	"lavenderblush3":       0xcdc1c5,
+	"lavenderblush4":       0x8b8386,
+	"lawngreen":            0x7cfc00,
+	"lemonchiffon":         0xfffacd,
+	"lemonchiffon1":        0xfffacd,
+	"lemonchiffon2":        0xeee9bf,
+	"lemonchiffon3":        0xcdc9a5,
+	"lemonchiffon4":        0x8b8970,
+	"lightblue":            0xadd8e6,
+	"lightblue1":           0xbfefff,
+	"lightblue2":           0xb2dfee,
+	"lightblue3":           0x9ac0cd,
+	"lightblue4":           0x68838b,
+	"lightcoral":           0xf08080,
+	"lightcyan":            0xe0ffff,
+	"lightcyan1":           0xe0ffff,
+	"lightcyan2":           0xd1eeee,
+	"lightcyan3":           0xb4cdcd,
+	"lightcyan4":           0x7a8b8b,
+	"lightgoldenrod":       0xeedd82,
+	"lightgoldenrod1":      0xffec8b,
+	"lightgoldenrod2":      0xeedc82,
+	"lightgoldenrod3":      0xcdbe70,
+	"lightgoldenrod4":      0x8b814c,
+	"lightgoldenrodyellow": 0xfafad2,
+	"lightgray":            0xd3d3d3,
+	"lightgreen":           0x90ee90,
+	"lightgrey":            0xd3d3d3,
+	"lightpink":            0xffb6c1,
+	"lightpink1":           0xffaeb9,
+	"lightpink2":           0xeea2ad,
+	"lightpink3":           0xcd8c95,
+	"lightpink4":           0x8b5f65,
+	"lightsalmon":          0xffa07a,
+	"lightsalmon1":         0xffa07a,
+	"lightsalmon2":         0xee9572,
+	"lightsalmon3":         0xcd8162,
+	"lightsalmon4":         0x8b5742,
+	"lightseagreen":        0x20b2aa,
+	"lightskyblue":         0x87cefa,
+	"lightskyblue1":        0xb0e2ff,
+	"lightskyblue2":        0xa4d3ee,
+	"lightskyblue3":        0x8db6cd,
+	"lightskyblue4":        0x607b8b,
+	"lightslateblue":       0x8470ff,
+	"lightslategray":       0x778899,
+	"lightslategrey":       0x778899,
+	"lightsteelblue":       0xb0c4de,
+	"lightsteelblue1":      0xcae1ff,
+	"lightsteelblue2":      0xbcd2ee,
+	"lightsteelblue3":      0xa2b5cd,
+	"lightsteelblue4":      0x6e7b8b,
+	"lightyellow":          0xffffe0,
+	"lightyellow1":         0xffffe0,
+	"lightyellow2":         0xeeeed1,
+	"lightyellow3":         0xcdcdb4,
+	"lightyellow4":         0x8b8b7a,
+	"limegreen":            0x32cd32,
+	"linen":                0xfaf0e6,
+	"magenta":              0xff00ff,
+	"magenta1":             0xff00ff,
+	"magenta2":             0xee00ee,
+	"magenta3":             0xcd00cd,
+	"magenta4":             0x8b008b,
+	"maroon":               0xb03060,
+	"maroon1":              0xff34b3,
+	"maroon2":              0xee30a7,
+	"maroon3":              0xcd2990,
+	"maroon4":              0x8b1c62,
+	"mediumaquamarine":     0x66cdaa,
+	"mediumblue":           0x0000cd,
+	"mediumorchid":         0xba55d3,
+	"mediumorchid1":        0xe066ff,
+	"mediumorchid2":        0xd15fee,
+	"mediumorchid3":        0xb452cd,
+	"mediumorchid4":        0x7a378b,
+	"mediumpurple":         0x9370db,
+	"mediumpurple1":        0xab82ff,
+	"mediumpurple2":        0x9f79ee,
+	"mediumpurple3":        0x8968cd,
+	"mediumpurple4":        0x5d478b,
+	"mediumseagreen":       0x3cb371,
+	"mediumslateblue":      0x7b68ee,
+	"mediumspringgreen":    0x00fa9a,
+	"mediumturquoise":      0x48d1cc,
+	"mediumvioletred":      0xc71585,
+	"midnightblue":         0x191970,
+	"mintcream":            0xf5fffa,
+	"mistyrose":            0xffe4e1,
+	"mistyrose1":           0xffe4e1,
+	"mistyrose2":           0xeed5d2,
+	"mistyrose3":           0xcdb7b5,
+	"mistyrose4":           0x8b7d7b,
+	"moccasin":             0xffe4b5,
+	"navajowhite":          0xffdead,
+	"navajowhite1":         0xffdead,
+	"navajowhite2":         0xeecfa1,
+	"navajowhite3":         0xcdb38b,
+	"navajowhite4":         0x8b795e,
+	"navy":                 0x000080,
+	"navyblue":             0x000080,
+	"oldlace":              0xfdf5e6,
+	"olivedrab":            0x6b8e23,
+	"olivedrab1":           0xc0ff3e,
+	"olivedrab2":           0xb3ee3a,
+	"olivedrab3":           0x9acd32,
+	"olivedrab4":           0x698b22,
+	"orange":               0xffa500,
+	"orange1":              0xffa500,
+	"orange2":              0xee9a00,
+	"orange3":              0xcd8500,
+	"orange4":              0x8b5a00,
+	"orangered":            0xff4500,
+	"orangered1":           0xff4500,
+	"orangered2":           0xee4000,
+	"orangered3":           0xcd3700,
+	"orangered4":           0x8b2500,
+	"orchid":               0xda70d6,
+	"orchid1":              0xff83fa,
+	"orchid2":              0xee7ae9,
+	"orchid3":              0xcd69c9,
+	"orchid4":              0x8b4789,
+	"palegoldenrod":        0xeee8aa,
+	"palegreen":            0x98fb98,
+	"palegreen1":           0x9aff9a,
+	"palegreen2":           0x90ee90,
+	"palegreen3":           0x7ccd7c,
+	"palegreen4":           0x548b54,
+	"paleturquoise":        0xafeeee,
+	"paleturquoise1":       0xbbffff,
+	"paleturquoise2":       0xaeeeee,
+	"paleturquoise3":       0x96cdcd,
+	"paleturquoise4":       0x668b8b,
+	"palevioletred":        0xdb7093,
+	"palevioletred1":       0xff82ab,
+	"palevioletred2":       0xee799f,
+	"palevioletred3":       0xcd6889,
+	"palevioletred4":       0x8b475d,
+	"papayawhip":           0xffefd5,
+	"peachpuff":            0xffdab9,
+	"peachpuff1":           0xffdab9,
+	"peachpuff2":           0xeecbad,
+	"peachpuff3":           0xcdaf95,
+	"peachpuff4":           0x8b7765,
+	"peru":                 0xcd853f,
+	"pink":                 0xffc0cb,
+	"pink1":                0xffb5c5,
+	"pink2":                0xeea9b8,
+	"pink3":                0xcd919e,
+	"pink4":                0x8b636c,
+	"plum":                 0xdda0dd,
+	"plum1":                0xffbbff,
+	"plum2":                0xeeaeee,
+	"plum3":                0xcd96cd,
+	"plum4":                0x8b668b,
+	"powderblue":           0xb0e0e6,
+	"purple":               0xa020f0,
+	"purple1":              0x9b30ff,
+	"purple2":              0x912cee,
+	"purple3":              0x7d26cd,
+	"purple4":              0x551a8b,
+	"red":                  0xff0000,
+	"red1":                 0xff0000,
+	"red2":                 0xee0000,
+	"red3":                 0xcd0000,
+	"red4":                 0x8b0000,
+	"rosybrown":            0xbc8f8f,
+	"rosybrown1":           0xffc1c1,
+	"rosybrown2":           0xeeb4b4,
+	"rosybrown3":           0xcd9b9b,
+	"rosybrown4":           0x8b6969,
+	"royalblue":            0x4169e1,
+	"royalblue1":           0x4876ff,
+	"royalblue2":           0x436eee,
+	"royalblue3":           0x3a5fcd,
+	"royalblue4":           0x27408b,
+	"saddlebrown":          0x8b4513,
+	"salmon":               0xfa8072,
+	"salmon1":              0xff8c69,
+	"salmon2":              0xee8262,
+	"salmon3":              0xcd7054,
+	"salmon4":              0x8b4c39,
+	"sandybrown":           0xf4a460,
+	"seagreen":             0x2e8b57,
+	"seagreen1":            0x54ff9f,
+	"seagreen2":            0x4eee94,
+	"seagreen3":            0x43cd80,
+	"seagreen4":            0x2e8b57,
+	"seashell":             0xfff5ee,
+	"seashell1":            0xfff5ee,
+	"seashell2":            0xeee5de,
+	"seashell3":            0xcdc5bf,
+	"seashell4":            0x8b8682,
+	"sienna":               0xa0522d,
+	"sienna1":              0xff8247,
+	"sienna2":              0xee7942,
+	"sienna3":              0xcd6839,
+	"sienna4":              0x8b4726,
+	"skyblue":              0x87ceeb,
+	"skyblue1":             0x87ceff,
+	"skyblue2":             0x7ec0ee,
+	"skyblue3":             0x6ca6cd,
+	"skyblue4":             0x4a708b,
+	"slateblue":            0x6a5acd,
+	"slateblue1":           0x836fff,
+	"slateblue2":           0x7a67ee,
+	"slateblue3":           0x6959cd,
+	"slateblue4":           0x473c8b,
+	"slategray":            0x708090,
+	"slategray1":           0xc6e2ff,
+	"slategray2":           0xb9d3ee,
+	"slategray3":           0x9fb6cd,
+	"slategray4":           0x6c7b8b,
+	"slategrey":            0x708090,
+	"snow":                 0xfffafa,
+	"snow1":                0xfffafa,
+	"snow2":                0xeee9e9,
+	"snow3":                0xcdc9c9,
+	"snow4":                0x8b8989,
+	"springgreen":          0x00ff7f,
+	"springgreen1":         0x00ff7f,
+	"springgreen2":         0x00ee76,
+	"springgreen3":         0x00cd66,
+	"springgreen4":         0x008b45,
+	"steelblue":            0x4682b4,
+	"steelblue1":           0x63b8ff,
+	"steelblue2":           0x5cacee,
+	"steelblue3":           0x4f94cd,
+	"steelblue4":           0x36648b,
+	"tan":                  0xd2b48c,
+	"tan1":                 0xffa54f,
+	"tan2":                 0xee9a49,
+	"tan3":                 0xcd853f,
+	"tan4":                 0x8b5a2b,
+	"thistle":              0xd8bfd8,
+	"thistle1":             0xffe1ff,
+	"thistle2":             0xeed2ee,
+	"thistle3":             0xcdb5cd,
+	"thistle4":             0x8b7b8b,
+	"tomato":               0xff6347,
+	"tomato1":              0xff6347,
+	"tomato2":              0xee5c42,
+	"tomato3":              0xcd4f39,
+	"tomato4":              0x8b3626,
+	"turquoise":            0x40e0d0,
+	"turquoise1":           0x00f5ff,
+	"turquoise2":           0x00e5ee,
+	"turquoise3":           0x00c5cd,
+	"turquoise4":           0x00868b,
+	"violet":               0xee82ee,
+	"violetred":            0xd02090,
+	"violetred1":           0xff3e96,
+	"violetred2":           0xee3a8c,
+	"violetred3":           0xcd3278,
+	"violetred4":           0x8b2252,
+	"wheat":                0xf5deb3,
+	"wheat1":               0xffe7ba,
+	"wheat2":               0xeed8ae,
+	"wheat3":               0xcdba96,
+	"wheat4":               0x8b7e66,
+	"white":                0xffffff,
+	"whitesmoke":           0xf5f5f5,
+	"yellow":               0xffff00,
+	"yellow1":              0xffff00,
+	"yellow2":              0xeeee00,
+	"yellow3":              0xcdcd00,
+	"yellow4":              0x8b8b00,
+	"yellowgreen":          0x9acd32,
+}
+
+// svgColors maps from colour names of the svg scheme to their 0xRRGGBB values.
+var svgColors = map[string]uint32{
+	"aliceblue":            0xf0f8ff,
+	"antiquewhite":         0xfaebd7,
+	"aqua":                 0x00ffff,
+	"aquamarine":           0x7fffd4,
+	"azure":                0xf0ffff,
+	"beige":                0xf5f5dc,
+	"bisque":               0xffe4c4,
+	"black":                0x000000,
+	"blanchedalmond":       0xffebcd,
+	"blue":                 0x0000ff,
+	"blueviolet":           0x8a2be2,
+	"brown":                0xa52a2a,
+	"burlywood":            0xdeb887,
+	"cadetblue":            0x5f9ea0,
+	"chartreuse":           0x7fff00,
+	"chocolate":            0xd2691e,
+	"coral":                0xff7f50,
+	"cornflowerblue":       0x6495ed,
+	"cornsilk":             0xfff8dc,
+	"crimson":              0xdc143c,
+	"cyan":                 0x00ffff,
+	"darkblue":             0x00008b,
+	"darkcyan":             0x008b8b,
+	"darkgoldenrod":        0xb8860b,
+	"darkgray":             0xa9a9a9,
+	"darkgreen":            0x006400,
+	"darkgrey":             0xa9a9a9,
+	"darkkhaki":            0xbdb76b,
+	"darkmagenta":          0x8b008b,
+	"darkolivegreen":       0x556b2f,
+	"darkorange":           0xff8c00,
+	"darkorchid":           0x9932cc,
+	"darkred":              0x8b0000,
+	"darksalmon":           0xe9967a,
+	"darkseagreen":         0x8fbc8f,
+	"darkslateblue":        0x483d8b,
+	"darkslategray":        0x2f4f4f,
+	"darkslategrey":        0x2f4f4f,
+	"darkturquoise":        0x00ced1,
+	"darkviolet":           0x9400d3,
+	"deeppink":             0xff1493,
+	"deepskyblue":          0x00bfff,
+	"dimgray":              0x696969,
+	"dimgrey":              0x696969,
+	"dodgerblue":           0x1e90ff,
+	"firebrick":            0xb22222,
+	"floralwhite":          0xfffaf0,
+	"forestgreen":          0x228b22,
+	"fuchsia":              0xff00ff,
+	"gainsboro":            0xdcdcdc,
+	"ghostwhite":           0xf8f8ff,
+	"gold":                 0xffd700,
+	"goldenrod":            0xdaa520,
+	"gray":                 0x808080,
+	"green":                0x008000,
+	"greenyellow":          0xadff2f,
+	"grey":                 0x808080,
+	"honeydew":             0xf0fff0,
+	"hotpink":              0xff69b4,
+	"indianred":            0xcd5c5c,
+	"indigo":               0x4b0082,
+	"ivory":                0xfffff0,
+	"khaki":                0xf0e68c,
+	"lavender":             0xe6e6fa,
+	"lavenderblush":        0xfff0f5,
+	"lawngreen":            0x7cfc00,
+	"lemonchiffon":         0xfffacd,
+	"lightblue":            0xadd8e6,
+	"lightcoral":           0xf08080,
+	"lightcyan":            0xe0ffff,
+	"lightgoldenrodyellow": 0xfafad2,
+	"lightgray":            0xd3d3d3,
+	"lightgreen":           0x90ee90,
+	"lightgrey":            0xd3d3d3,
+	"lightpink":            0xffb6c1,
+	"lightsalmon":          0xffa07a,
+	"lightseagreen":        0x20b2aa,
+	"lightskyblue":         0x87cefa,
+	"lightslategray":       0x778899,
+	"lightslategrey":       0x778899,
+	"lightsteelblue":       0xb0c4de,
+	"lightyellow":          0xffffe0,
+	"lime":                 0x00ff00,
+	"limegreen":            0x32cd32,
+	"linen":                0xfaf0e6,
+	"magenta":              0xff00ff,
+	"maroon":               0x800000,
+	"mediumaquamarine":     0x66cdaa,
+	"mediumblue":           0x0000cd,
+	"mediumorchid":         0xba55d3,
+	"mediumpurple":         0x9370db,
+	"mediumseagreen":       0x3cb371,
+	"mediumslateblue":      0x7b68ee,
+	"mediumspringgreen":    0x00fa9a,
+	"mediumturquoise":      0x48d1cc,
+	"mediumvioletred":      0xc71585,
+	"midnightblue":         0x191970,
+	"mintcream":            0xf5fffa,
+	"mistyrose":            0xffe4e1,
+	"moccasin":             0xffe4b5,
+	"navajowhite":          0xffdead,
+	"navy":                 0x000080,
+	"oldlace":              0xfdf5e6,
+	"olive":                0x808000,
+	"olivedrab":            0x6b8e23,
+	"orange":               0xffa500,
+	"orangered":            0xff4500,
+	"orchid":               0xda70d6,
+	"palegoldenrod":        0xeee8aa,
+	"palegreen":            0x98fb98,
+	"paleturquoise":        0xafeeee,
+	"palevioletred":        0xdb7093,
+	"papayawhip":           0xffefd5,
+	"peachpuff":            0xffdab9,
+	"peru":                 0xcd853f,
+	"pink":                 0xffc0cb,
+	"plum":                 0xdda0dd,
+	"powderblue":           0xb0e0e6,
+	"purple":               0x800080,
+	"red":                  0xff0000,
+	"rosybrown":            0xbc8f8f,
+	"royalblue":            0x4169e1,
+	"saddlebrown":          0x8b4513,
+	"salmon":               0xfa8072,
+	"sandybrown":           0xf4a460,
+	"seagreen":             0x2e8b57,
+	"seashell":             0xfff5ee,
+	"sienna":               0xa0522d,
+	"silver":               0xc0c0c0,
+	"skyblue":              0x87ceeb,
+	"slateblue":            0x6a5acd,
+	"slategray":            0x708090,
+	"slategrey":            0x708090,
+	"snow":                 0xfffafa,
+	"springgreen":          0x00ff7f,
+	"steelblue":            0x4682b4,
+	"tan":                  0xd2b48c,
+	"teal":                 0x008080,
+	"thistle":              0xd8bfd8,
+	"tomato":               0xff6347,
+	"turquoise":            0x40e0d0,
+	"violet":               0xee82ee,
+	"wheat":                0xf5deb3,
+	"white":                0xffffff,
+	"whitesmoke":           0xf5f5f5,
+	"yellow":               0xffff00,
+	"yellowgreen":          0x9acd32,
+}
+
+// brewerSchemes maps from the names of brewer colour schemes to their colours,
+// as concatenated RRGGBB values. The colours of a scheme are named by their
+// index, starting at 1.
+var brewerSchemes = map[string]string{
+	"accent3":    "7fc97fbeaed4fdc086",
+	"accent4":    "7fc97fbeaed4fdc086ffff99",
+	"accent5":    "7fc97fbeaed4fdc086ffff99386cb0",
+	"accent6":    "7fc97fbeaed4fdc086ffff99386cb0f0027f",
+	"accent7":    "7fc97fbeaed4fdc086ffff99386cb0f0027fbf5b17",
+	"accent8":    "7fc97fbeaed4fdc086ffff99386cb0f0027fbf5b17666666",
+	"blues3":     "deebf79ecae13182bd",
+	"blues4":     "eff3ffbdd7e76baed62171b5",
+	"blues5":     "eff3ffbdd7e76baed63182bd08519c",
+	"blues6":     "eff3ffc6dbef9ecae16baed63182bd08519c",
+	"blues7":     "eff3ffc6dbef9ecae16baed64292c62171b5084594",
+	"blues8":     "f7fbffdeebf7c6dbef9ecae16baed64292c62171b5084594",
+	"blues9":     "f7fbffdeebf7c6dbef9ecae16baed64292c62171b508519c08306b",
+	"brbg10":     "5430058c510abf812ddfc27df6e8c3c7eae580cdc135978f01665e003c30",
+	"brbg11":     "5430058c510abf812ddfc27df6e8c3f5f5f5c7eae580cdc135978f01665e003c30",
+	"brbg3":      "d8b365f5f5f55ab4ac",
+	"brbg4":      "a6611adfc27d80cdc1018571",
+	"brbg5":      "a6611adfc27df5f5f580cdc1018571",
+	"brbg6":      "8c510ad8b365f6e8c3c7eae55ab4ac01665e",
+	"brbg7":      "8c510ad8b365f6e8c3f5f5f5c7eae55ab4ac01665e",
+	"brbg8":      "8c510abf812ddfc27df6e8c3c7eae580cdc135978f01665e",
+	"brbg9":      "8c510abf812ddfc27df6e8c3f5f5f5c7eae580cdc135978f01665e",
+	"bugn3":      "e5f5f999d8c92ca25f",
+	"bugn4":      "edf8fbb2e2e266c2a4238b45",
+	"bugn5":      "edf8fbb2e2e266c2a42ca25f006d2c",
+	"bugn6":      "edf8fbccece699d8c966c2a42ca25f006d2c",
+	"bugn7":      "edf8fbccece699d8c966c2a441ae76238b45005824",
+	"bugn8":      "f7fcfde5f5f9ccece699d8c966c2a441ae76238b45005824",
+	"bugn9":      "f7fcfde5f5f9ccece699d8c966c2a441ae76238b45006d2c00441b",
+	"bupu3":      "e0ecf49ebcda8856a7",
+	"bupu4":      "edf8fbb3cde38c96c688419d",
+	"bupu5":      "edf8fbb3cde38c96c68856a7810f7c",
+	"bupu6":      "edf8fbbfd3e69ebcda8c96c68856a7810f7c",
+	"bupu7":      "edf8fbbfd3e69ebcda8c96c68c6bb188419d6e016b",
+	"bupu8":      "f7fcfde0ecf4bfd3e69ebcda8c96c68c6bb188419d6e016b",
+	"bupu9":      "f7fcfde0ecf4bfd3e69ebcda8c96c68c6bb188419d810f7c4d004b",
+	"dark23":     "1b9e77d95f027570b3",
+	"dark24":     "1b9e77d95f027570b3e7298a",
+	"dark25":     "1b9e77d95f027570b3e7298a66a61e",
+	"dark26":     "1b9e77d95f027570b3e7298a66a61ee6ab02",
+	"dark27":     "1b9e77d95f027570b3e7298a66a61ee6ab02a6761d",
+	"dark28":     "1b9e77d95f027570b3e7298a66a61ee6ab02a6761d666666",
+	"gnbu3":      "e0f3dba8ddb543a2ca",
+	"gnbu4":      "f0f9e8bae4bc7bccc42b8cbe",
+	"gnbu5":      "f0f9e8bae4bc7bccc443a2ca0868ac",
+	"gnbu6":      "f0f9e8ccebc5a8ddb57bccc443a2ca0868ac",
+	"gnbu7":      "f0f9e8ccebc5a8ddb57bccc44eb3d32b8cbe08589e",
+	"gnbu8":      "f7fcf0e0f3dbccebc5a8ddb57bccc44eb3d32b8cbe08589e",
+	"gnbu9":      "f7fcf0e0f3dbccebc5a8ddb57bccc44eb3d32b8cbe0868ac084081",
+	"greens3":    "e5f5e0a1d99b31a354",
+	"greens4":    "edf8e9bae4b374c476238b45",
+	"greens5":    "edf8e9bae4b374c47631a354006d2c",
+	"greens6":    "edf8e9c7e9c0a1d99b74c47631a354006d2c",
+	"greens7":    "edf8e9c7e9c0a1d99b74c47641ab5d238b45005a32",
+	"greens8":    "f7fcf5e5f5e0c7e9c0a1d99b74c47641ab5d238b45005a32",
+	"greens9":    "f7fcf5e5f5e0c7e9c0a1d99b74c47641ab5d238b45006d2c00441b",
+	"greys3":     "f0f0f0bdbdbd636363",
+	"greys4":     "f7f7f7cccccc969696525252",
+	"greys5":     "f7f7f7cccccc969696636363252525",
+	"greys6":     "f7f7f7d9d9d9bdbdbd969696636363252525",
+	"greys7":     "f7f7f7d9d9d9bdbdbd969696737373525252252525",
+	"greys8":     "fffffff0f0f0d9d9d9bdbdbd969696737373525252252525",
+	"greys9":     "fffffff0f0f0d9d9d9bdbdbd969696737373525252252525000000",
+	"oranges3":   "fee6cefdae6be6550d",
+	"oranges4":   "feeddefdbe85fd8d3cd94701",
+	"oranges5":   "feeddefdbe85fd8d3ce6550da63603",
+	"oranges6":   "feeddefdd0a2fdae6bfd8d3ce6550da63603",
+	"oranges7":   "feeddefdd0a2fdae6bfd8d3cf16913d948018c2d04",
+	"oranges8":   "fff5ebfee6cefdd0a2fdae6bfd8d3cf16913d948018c2d04",
+	"oranges9":   "fff5ebfee6cefdd0a2fdae6bfd8d3cf16913d94801a636037f2704",
+	"orrd3":      "fee8c8fdbb84e34a33",
+	"orrd4":      "fef0d9fdcc8afc8d59d7301f",
+	"orrd5":      "fef0d9fdcc8afc8d59e34a33b30000",
+	"orrd6":      "fef0d9fdd49efdbb84fc8d59e34a33b30000",
+	"orrd7":      "fef0d9fdd49efdbb84fc8d59ef6548d7301f990000",
+	"orrd8":      "fff7ecfee8c8fdd49efdbb84fc8d59ef6548d7301f990000",
+	"orrd9":      "fff7ecfee8c8fdd49efdbb84fc8d59ef6548d7301fb300007f0000",
+	"paired10":   "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6fff7f00cab2d66a3d9a",
+	"paired11":   "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6fff7f00cab2d66a3d9affff99",
+	"paired12":   "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6fff7f00cab2d66a3d9affff99b15928",
+	"paired3":    "a6cee31f78b4b2df8a",
+	"paired4":    "a6cee31f78b4b2df8a33a02c",
+	"paired5":    "a6cee31f78b4b2df8a33a02cfb9a99",
+	"paired6":    "a6cee31f78b4b2df8a33a02cfb9a99e31a1c",
+	"paired7":    "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6f",
+	"paired8":    "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6fff7f00",
+	"paired9":    "a6cee31f78b4b2df8a33a02cfb9a99e31a1cfdbf6fff7f00cab2d6",
+	"pastel13":   "fbb4aeb3cde3ccebc5",
+	"pastel14":   "fbb4aeb3cde3ccebc5decbe4",
+	"pastel15":   "fbb4aeb3cde3ccebc5decbe4fed9a6",
+	"pastel16":   "fbb4aeb3cde3ccebc5decbe4fed9a6ffffcc",
+	"pastel17":   "fbb4aeb3cde3ccebc5decbe4fed9a6ffffcce5d8bd",
+	"pastel18":   "fbb4aeb3cde3ccebc5decbe4fed9a6ffffcce5d8bdfddaec",
+	"pastel19":   "fbb4aeb3cde3ccebc5decbe4fed9a6ffffcce5d8bdfddaecf2f2f2",
+	"pastel23":   "b3e2cdfdcdaccbd5e8",
+	"pastel24":   "b3e2cdfdcdaccbd5e8f4cae4",
+	"pastel25":   "b3e2cdfdcdaccbd5e8f4cae4e6f5c9",
+	"pastel26":   "b3e2cdfdcdaccbd5e8f4cae4e6f5c9fff2ae",
+	"pastel27":   "b3e2cdfdcdaccbd5e8f4cae4e6f5c9fff2aef1e2cc",
+	"pastel28":   "b3e2cdfdcdaccbd5e8f4cae4e6f5c9fff2aef1e2cccccccc",
+	"piyg10":     "8e0152c51b7dde77aef1b6dafde0efe6f5d0b8e1867fbc414d9221276419",
+	"piyg11":     "8e0152c51b7dde77aef1b6dafde0eff7f7f7e6f5d0b8e1867fbc414d9221276419",
+	"piyg3":      "e9a3c9f7f7f7a1d76a",
+	"piyg4":      "d01c8bf1b6dab8e1864dac26",
+	"piyg5":      "d01c8bf1b6daf7f7f7b8e1864dac26",
+	"piyg6":      "c51b7de9a3c9fde0efe6f5d0a1d76a4d9221",
+	"piyg7":      "c51b7de9a3c9fde0eff7f7f7e6f5d0a1d76a4d9221",
+	"piyg8":      "c51b7dde77aef1b6dafde0efe6f5d0b8e1867fbc414d9221",
+	"piyg9":      "c51b7dde77aef1b6dafde0eff7f7f7e6f5d0b8e1867fbc414d9221",
+	"prgn10":     "40004b762a839970abc2a5cfe7d4e8d9f0d3a6dba05aae611b783700441b",
+	"prgn11":     "40004b762a839970abc2a5cfe7d4e8f7f7f7d9f0d3a6dba05aae611b783700441b",
+	"prgn3":      "af8dc3f7f7f77fbf7b",
+	"prgn4":      "7b3294c2a5cfa6dba0008837",
+	"prgn5":      "7b3294c2a5cff7f7f7a6dba0008837",
+	"prgn6":      "762a83af8dc3e7d4e8d9f0d37fbf7b1b7837",
+	"prgn7":      "762a83af8dc3e7d4e8f7f7f7d9f0d37fbf7b1b7837",
+	"prgn8":      "762a839970abc2a5cfe7d4e8d9f0d3a6dba05aae611b7837",
+	"prgn9":      "762a839970abc2a5cfe7d4e8f7f7f7d9f0d3a6dba05aae611b7837",
+	"pubu3":      "ece7f2a6bddb2b8cbe",
+	"pubu4":      "f1eef6bdc9e174a9cf0570b0",
+	"pubu5":      "f1eef6bdc9e174a9cf2b8cbe045a8d",
+	"pubu6":      "f1eef6d0d1e6a6bddb74a9cf2b8cbe045a8d",
+	"pubu7":      "f1eef6d0d1e6a6bddb74a9cf3690c00570b0034e7b",
+	"pubu8":      "fff7fbece7f2d0d1e6a6bddb74a9cf3690c00570b0034e7b",
+	"pubu9":      "fff7fbece7f2d0d1e6a6bddb74a9cf3690c00570b0045a8d023858",
+	"pubugn3":    "ece2f0a6bddb1c9099",
+	"pubugn4":    "f6eff7bdc9e167a9cf02818a",
+	"pubugn5":    "f6eff7bdc9e167a9cf1c9099016c59",
+	"pubugn6":    "f6eff7d0d1e6a6bddb67a9cf1c9099016c59",
+	"pubugn7":    "f6eff7d0d1e6a6bddb67a9cf3690c002818a016450",
+	"pubugn8":    "fff7fbece2f0d0d1e6a6bddb67a9cf3690c002818a016450",
+	"pubugn9":    "fff7fbece2f0d0d1e6a6bddb67a9cf3690c002818a016c59014636",
+	"puor10":     "2d004b5427888073acb2abd2d8daebfee0b6fdb863e08214b358067f3b08",
+	"puor11":     "2d004b5427888073acb2abd2d8daebf7f7f7fee0b6fdb863e08214b358067f3b08",
+	"puor3":      "998ec3f7f7f7f1a340",
+	"puor4":      "5e3c99b2abd2fdb863e66101",
+	"puor5":      "5e3c99b2abd2f7f7f7fdb863e66101",
+	"puor6":      "542788998ec3d8daebfee0b6f1a340b35806",
+	"puor7":      "542788998ec3d8daebf7f7f7fee0b6f1a340b35806",
+	"puor8":      "5427888073acb2abd2d8daebfee0b6fdb863e08214b35806",
+	"puor9":      "5427888073acb2abd2d8daebf7f7f7fee0b6fdb863e08214b35806",
+	"purd3":      "e7e1efc994c7dd1c77",
+	"purd4":      "f1eef6d7b5d8df65b0ce1256",
+	"purd5":      "f1eef6d7b5d8df65b0dd1c77980043",
+	"purd6":      "f1eef6d4b9dac994c7df65b0dd1c77980043",
+	"purd7":      "f1eef6d4b9dac994c7df65b0e7298ace125691003f",
+	"purd8":      "f7f4f9e7e1efd4b9dac994c7df65b0e7298ace125691003f",
+	"purd9":      "f7f4f9e7e1efd4b9dac994c7df65b0e7298ace125698004367001f",
+	"purples3":   "efedf5bcbddc756bb1",
+	"purples4":   "f2f0f7cbc9e29e9ac86a51a3",
+	"purples5":   "f2f0f7cbc9e29e9ac8756bb154278f",
+	"purples6":   "f2f0f7dadaebbcbddc9e9ac8756bb154278f",
+	"purples7":   "f2f0f7dadaebbcbddc9e9ac8807dba6a51a34a1486",
+	"purples8":   "fcfbfdefedf5dadaebbcbddc9e9ac8807dba6a51a34a1486",
+	"purples9":   "fcfbfdefedf5dadaebbcbddc9e9ac8807dba6a51a354278f3f007d",
+	"rdbu10":     "67001fb2182bd6604df4a582fddbc7d1e5f092c5de4393c32166ac053061",
+	"rdbu11":     "67001fb2182bd6604df4a582fddbc7f7f7f7d1e5f092c5de4393c32166ac053061",
+	"rdbu3":      "ef8a62f7f7f767a9cf",
+	"rdbu4":      "ca0020f4a58292c5de0571b0",
+	"rdbu5":      "ca0020f4a582f7f7f792c5de0571b0",
+	"rdbu6":      "b2182bef8a62fddbc7d1e5f067a9cf2166ac",
+	"rdbu7":      "b2182bef8a62fddbc7f7f7f7d1e5f067a9cf2166ac",
+	"rdbu8":      "b2182bd6604df4a582fddbc7d1e5f092c5de4393c32166ac",
+	"rdbu9":      "b2182bd6604df4a582fddbc7f7f7f7d1e5f092c5de4393c32166ac",
+	"rdgy10":     "67001fb2182bd6604df4a582fddbc7e0e0e0bababa8787874d4d4d1a1a1a",
+	"rdgy11":     "67001fb2182bd6604df4a582fddbc7ffffffe0e0e0bababa8787874d4d4d1a1a1a",
+	"rdgy3":      "ef8a62ffffff999999",
+	"rdgy4":      "ca0020f4a582bababa404040",
+	"rdgy5":      "ca0020f4a582ffffffbababa404040",
+	"rdgy6":      "b2182bef8a62fddbc7e0e0e09999994d4d4d",
+	"rdgy7":      "b2182bef8a62fddbc7ffffffe0e0e09999994d4d4d",
+	"rdgy8":      "b2182bd6604df4a582fddbc7e0e0e0bababa8787874d4d4d",
+	"rdgy9":      "b2182bd6604df4a582fddbc7ffffffe0e0e0bababa8787874d4d4d",
+	"rdpu3":      "fde0ddfa9fb5c51b8a",
+	"rdpu4":      "feebe2fbb4b9f768a1ae017e",
+	"rdpu5":      "feebe2fbb4b9f768a1c51b8a7a0177",
+	"rdpu6":      "feebe2fcc5c0fa9fb5f768a1c51b8a7a0177",
+	"rdpu7":      "feebe2fcc5c0fa9fb5f768a1dd3497ae017e7a0177",
+	"rdpu8":      "fff7f3fde0ddfcc5c0fa9fb5f768a1dd3497ae017e7a0177",
+	"rdpu9":      "fff7f3fde0ddfcc5c0fa9fb5f768a1dd3497ae017e7a017749006a",
+	"rdylbu10":   "a50026d73027f46d43fdae61fee090e0f3f8abd9e974add14575b4313695",
+	"rdylbu11":   "a50026d73027f46d43fdae61fee090ffffbfe0f3f8abd9e974add14575b4313695",
+	"rdylbu3":    "fc8d59ffffbf91bfdb",
+	"rdylbu4":    "d7191cfdae61abd9e92c7bb6",
+	"rdylbu5":    "d7191cfdae61ffffbfabd9e92c7bb6",
+	"rdylbu6":    "d73027fc8d59fee090e0f3f891bfdb4575b4",
+	"rdylbu7":    "d73027fc8d59fee090ffffbfe0f3f891bfdb4575b4",
+	"rdylbu8":    "d73027f46d43fdae61fee090e0f3f8abd9e974add14575b4",
+	"rdylbu9":    "d73027f46d43fdae61fee090ffffbfe0f3f8abd9e974add14575b4",
+	"rdylgn10":   "a50026d73027f46d43fdae61fee08bd9ef8ba6d96a66bd631a9850006837",
+	"rdylgn11":   "a50026d73027f46d43fdae61fee08bffffbfd9ef8ba6d96a66bd631a9850006837",
+	"rdylgn3":    "fc8d59ffffbf91cf60",
+	"rdylgn4":    "d7191cfdae61a6d96a1a9641",
+	"rdylgn5":    "d7191cfdae61ffffbfa6d96a1a9641",
+	"rdylgn6":    "d73027fc8d59fee08bd9ef8b91cf601a9850",
+	"rdylgn7":    "d73027fc8d59fee08bffffbfd9ef8b91cf601a9850",
+	"rdylgn8":    "d73027f46d43fdae61fee08bd9ef8ba6d96a66bd631a9850",
+	"rdylgn9":    "d73027f46d43fdae61fee08bffffbfd9ef8ba6d96a66bd631a9850",
+	"reds3":      "fee0d2fc9272de2d26",
+	"reds4":      "fee5d9fcae91fb6a4acb181d",
+	"reds5":      "fee5d9fcae91fb6a4ade2d26a50f15",
+	"reds6":      "fee5d9fcbba1fc9272fb6a4ade2d26a50f15",
+	"reds7":      "fee5d9fcbba1fc9272fb6a4aef3b2ccb181d99000d",
+	"reds8":      "fff5f0fee0d2fcbba1fc9272fb6a4aef3b2ccb181d99000d",
+	"reds9":      "fff5f0fee0d2fcbba1fc9272fb6a4aef3b2ccb181da50f1567000d",
+	"set13":      "e41a1c377eb84daf4a",
+	"set14":      "e41a1c377eb84daf4a984ea3",
+	"set15":      "e41a1c377eb84daf4a984ea3ff7f00",
+	"set16":      "e41a1c377eb84daf4a984ea3ff7f00ffff33",
+	"set17":      "e41a1c377eb84daf4a984ea3ff7f00ffff33a65628",
+	"set18":      "e41a1c377eb84daf4a984ea3ff7f00ffff33a65628f781bf",
+	"set19":      "e41a1c377eb84daf4a984ea3ff7f00ffff33a65628f781bf999999",
+	"set23":      "66c2a5fc8d628da0cb",
+	"set24":      "66c2a5fc8d628da0cbe78ac3",
+	"set25":      "66c2a5fc8d628da0cbe78ac3a6d854",
+	"set26":      "66c2a5fc8d628da0cbe78ac3a6d854ffd92f",
+	"set27":      "66c2a5fc8d628da0cbe78ac3a6d854ffd92fe5c494",
+	"set28":      "66c2a5fc8d628da0cbe78ac3a6d854ffd92fe5c494b3b3b3",
+	"set310":     "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69fccde5d9d9d9bc80bd",
+	"set311":     "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69fccde5d9d9d9bc80bdccebc5",
+	"set312":     "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69fccde5d9d9d9bc80bdccebc5ffed6f",
+	"set33":      "8dd3c7ffffb3bebada",
+	"set34":      "8dd3c7ffffb3bebadafb8072",
+	"set35":      "8dd3c7ffffb3bebadafb807280b1d3",
+	"set36":      "8dd3c7ffffb3bebadafb807280b1d3fdb462",
+	"set37":      "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69",
+	"set38":      "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69fccde5",
+	"set39":      "8dd3c7ffffb3bebadafb807280b1d3fdb462b3de69fccde5d9d9d9",
+	"spectral10": "9e0142d53e4ff46d43fdae61fee08be6f598abdda466c2a53288bd5e4fa2",
+	"spectral11": "9e0142d53e4ff46d43fdae61fee08bffffbfe6f598abdda466c2a53288bd5e4fa2",
+	"spectral3":  "fc8d59ffffbf99d594",
+	"spectral4":  "d7191cfdae61abdda42b83ba",
+	"spectral5":  "d7191cfdae61ffffbfabdda42b83ba",
+	"spectral6":  "d53e4ffc8d59fee08be6f59899d5943288bd",
+	"spectral7":  "d53e4ffc8d59fee08bffffbfe6f59899d5943288bd",
+	"spectral8":  "d53e4ff46d43fdae61fee08be6f598abdda466c2a53288bd",
+	"spectral9":  "d53e4ff46d43fdae61fee08bffffbfe6f598abdda466c2a53288bd",
+	"ylgn3":      "f7fcb9addd8e31a354",
+	"ylgn4":      "ffffccc2e69978c679238443",
+	"ylgn5":      "ffffccc2e69978c67931a354006837",
+	"ylgn6":      "ffffccd9f0a3addd8e78c67931a354006837",
+	"ylgn7":      "ffffccd9f0a3addd8e78c67941ab5d238443005a32",
+	"ylgn8":      "ffffe5f7fcb9d9f0a3addd8e78c67941ab5d238443005a32",
+	"ylgn9":      "ffffe5f7fcb9d9f0a3addd8e78c67941ab5d238443006837004529",
+	"ylgnbu3":    "edf8b17fcdbb2c7fb8",
+	"ylgnbu4":    "ffffcca1dab441b6c4225ea8",
+	"ylgnbu5":    "ffffcca1dab441b6c42c7fb8253494",
+	"ylgnbu6":    "ffffccc7e9b47fcdbb41b6c42c7fb8253494",
+	"ylgnbu7":    "ffffccc7e9b47fcdbb41b6c41d91c0225ea80c2c84",
+	"ylgnbu8":    "ffffd9edf8b1c7e9b47fcdbb41b6c41d91c0225ea80c2c84",
+	"ylgnbu9":    "ffffd9edf8b1c7e9b47fcdbb41b6c41d91c0225ea8253494081d58",
+	"ylorbr3":    "fff7bcfec44fd95f0e",
+	"ylorbr4":    "ffffd4fed98efe9929cc4c02",
+	"ylorbr5":    "ffffd4fed98efe9929d95f0e993404",
+	"ylorbr6":    "ffffd4fee391fec44ffe9929d95f0e993404",
+	"ylorbr7":    "ffffd4fee391fec44ffe9929ec7014cc4c028c2d04",
+	"ylorbr8":    "ffffe5fff7bcfee391fec44ffe9929ec7014cc4c028c2d04",
+	"ylorbr9":    "ffffe5fff7bcfee391fec44ffe9929ec7014cc4c02993404662506",
+	"ylorrd3":    "ffeda0feb24cf03b20",
+	"ylorrd4":    "ffffb2fecc5cfd8d3ce31a1c",
+	"ylorrd5":    "ffffb2fecc5cfd8d3cf03b20bd0026",
+	"ylorrd6":    "ffffb2fed976feb24cfd8d3cf03b20bd0026",
+	"ylorrd7":    "ffffb2fed976feb24cfd8d3cfc4e2ae31a1cb10026",
+	"ylorrd8":    "ffffccffeda0fed976feb24cfd8d3cfc4e2ae31a1cb10026",
+	"ylorrd9":    "ffffccffeda0fed976feb24cfd8d3cfc4e2ae31a1cbd0026800026",
+}
@@ -0,0 +1,75 @@
+package dot
+
+// This file defines the weakly connected components of graphs.
+
+// WeaklyConnectedComponents returns the weakly connected components of the
+// graph, ignoring the direction of edges. Components are ordered by their
+// first node, and the nodes of each component are in node order.
+func (g *Graph) WeaklyConnectedComponents() [][]*Node {
+	n := len(g.Nodes.Nodes)
+	adj := make([][]*Node, n)
+	for _, arc := range g.Arcs() {
+		adj[arc.Src.Index] = append(adj[arc.Src.Index], arc.Dst)
+		adj[arc.Dst.Index] = append(adj[arc.Dst.Index], arc.Src)
+	}
+	compOf := make([]int, n)
+	for i := range compOf {
+		compOf[i] = -1
+	}
+	ncomps := 0
+	for _, root := range g.Nodes.Nodes {
+		if compOf[root.Index] != -1 {
+			continue
+		}
+		compOf[root.Index] = ncomps
+		for stack := []*Node{root}; len(stack) > 0; {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range adj[v.Index] {
+				if compOf[w.Index] == -1 {
+					compOf[w.Index] = ncomps
+					stack = append(stack, w)
+				}
+			}
+		}
+		ncomps++
+	}
+	comps := make([][]*Node, ncomps)
+	for _, node := range g.Nodes.Nodes {
+		comps[compOf[node.Index]] = append(comps[compOf[node.Index]], node)
+	}
+	return comps
+}
+
+// Split returns a new graph for each weakly connected component of g, akin to
+// the ccomps tool of Graphviz. Each graph has the name, type and attributes of
+// g, along with the nodes and edges of the component and the subgraphs
+// containing them. Subgraphs without nodes are not retained.
+func Split(g *Graph) []*Graph {
+	comps := g.WeaklyConnectedComponents()
+	compOf := make([]int, len(g.Nodes.Nodes))
+	graphs := make([]*Graph, len(comps))
+	for i, comp := range comps {
+		for _, node := range comp {
+			compOf[node.Index] = i
+		}
+	}
+	for i := range comps {
+		i := i
+		graphs[i] = copyGraph(g, func(node *Node) bool {
+			return compOf[node.Index] == i
+		})
+	}
+	// Each arc of an edge belongs to the same component.
+	for _, edge := range g.Edges.Edges {
+		srcs := g.endpoints(edge.Src)
+		if len(srcs) == 0 || len(g.endpoints(edge.Dst)) == 0 {
+			continue
+		}
+		copyEdge(graphs[compOf[srcs[0].Index]], edge)
+	}
+	for _, c := range graphs {
+		linkNodes(c)
+	}
+	return graphs
+}
@@ -0,0 +1,51 @@
+package dot
+
+import "testing"
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a -> b -> c -> a;
+		c -> d -> e -> d;
+	}`))
+	check(t, err)
+	comps := g.StronglyConnectedComponents()
+	assert(t, "number of components", len(comps), 2)
+	assert(t, "first component", nodeNames(comps[0]), "[d e]")
+	assert(t, "second component", nodeNames(comps[1]), "[a b c]")
+}
+
+func TestSplit(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_0 {
+			color=blue;
+			a -> b;
+			x;
+		}
+		b -> c [label=bc];
+		x -> y;
+		z [shape=box];
+	}`))
+	check(t, err)
+	comps := g.WeaklyConnectedComponents()
+	assert(t, "number of components", len(comps), 3)
+
+	graphs := Split(g)
+	assert(t, "number of graphs", len(graphs), 3)
+	abc, xy, z := graphs[0], graphs[1], graphs[2]
+	assert(t, "nodes", nodeNames(abc.Nodes.Nodes), "[a b c]")
+	assert(t, "edges", edgeNames(abc), "[a->b b->c]")
+	assert(t, "graph attribute", abc.Attrs["rankdir"], "LR")
+	assert(t, "edge attribute", abc.Edges.SrcToDsts["b"]["c"].Attrs["label"], "bc")
+	assert(t, "subgraph", abc.IsSubGraph("cluster_0"), true)
+	assert(t, "subgraph attribute", abc.SubGraphs.SubGraphs["cluster_0"].Attrs["color"], "blue")
+	assert(t, "subgraph membership", abc.Relations.ParentToChildren["cluster_0"]["a"], true)
+	assert(t, "subgraph membership", abc.Relations.ParentToChildren["cluster_0"]["x"], false)
+
+	assert(t, "nodes", nodeNames(xy.Nodes.Nodes), "[x y]")
+	assert(t, "subgraph membership", xy.Relations.ParentToChildren["cluster_0"]["x"], true)
+
+	assert(t, "nodes", nodeNames(z.Nodes.Nodes), "[z]")
+	assert(t, "node attribute", z.Nodes.Lookup["z"].Attrs["shape"], "box")
+	assert(t, "subgraph", z.IsSubGraph("cluster_0"), false)
+}
@@ -0,0 +1,149 @@
+package dot
+
+// This file defines helper functions for deriving new graphs from existing
+// ones.
+
+import (
+	"fmt"
+	"sort"
+)
+
+// copyGraph returns a new graph with the name, type and attributes of g, and a
+// copy of each node of g for which keep returns true, along with the subgraphs
+// containing them and the subgraphs enclosing those. If keep is nil, every node
+// and subgraph is copied. Edges are not copied.
+func copyGraph(g *Graph, keep func(node *Node) bool) *Graph {
+	c := NewGraph()
+	c.Name = g.Name
+	c.Directed = g.Directed
+	c.Strict = g.Strict
+	c.Attrs = g.Attrs.Copy()
+	c.HTMLAttrs = g.HTMLAttrs.Copy()
+	for _, node := range g.Nodes.Nodes {
+		if keep != nil && !keep(node) {
+			continue
+		}
+		c.Nodes.Add(&Node{Name: node.Name, Attrs: node.Attrs.Copy(), HTMLAttrs: node.HTMLAttrs.Copy()})
+		for _, parent := range sortedKeys(g.Relations.ChildToParents[node.Name]) {
+			c.Relations.Add(parent, node.Name)
+		}
+	}
+	for _, sub := range g.SubGraphs.Sorted() {
+		if keep != nil && len(c.Relations.ParentToChildren[sub.Name]) == 0 {
+			continue
+		}
+		copySubGraph(c, g, sub.Name)
+	}
+	return c
+}
+
+// copySubGraph adds a copy of the named subgraph of src to g, unless already
+// present, along with the subgraphs of src enclosing it. Top-level subgraphs of
+// src are top-level subgraphs of g.
+func copySubGraph(g, src *Graph, name string) {
+	if g.IsSubGraph(name) {
+		return
+	}
+	sub := src.SubGraphs.SubGraphs[name]
+	parent := sub.Parent
+	switch {
+	case parent != name && src.IsSubGraph(parent):
+		copySubGraph(g, src, parent)
+	case parent == src.Name:
+		parent = g.Name
+	}
+	g.SubGraphs.Add(name)
+	s := g.SubGraphs.SubGraphs[name]
+	s.Attrs = sub.Attrs.Copy()
+	s.HTMLAttrs = sub.HTMLAttrs.Copy()
+	s.Parent = parent
+}
+
+// subGraphParent returns the innermost subgraph of src enclosing the named
+// subgraph which is also a subgraph of g, or the root graph of g if none.
+func subGraphParent(g, src *Graph, name string) string {
+	seen := map[string]bool{name: true}
+	for parent := src.SubGraphs.SubGraphs[name].Parent; src.IsSubGraph(parent) && !seen[parent]; parent = src.SubGraphs.SubGraphs[parent].Parent {
+		if g.IsSubGraph(parent) {
+			return parent
+		}
+		seen[parent] = true
+	}
+	return g.Name
+}
+
+// copyEdge adds a copy of the given edge to g.
+func copyEdge(g *Graph, edge *Edge) {
+	g.Edges.Add(&Edge{
+		Src:       edge.Src,
+		SrcPort:   edge.SrcPort,
+		Dst:       edge.Dst,
+		DstPort:   edge.DstPort,
+		Dir:       edge.Dir,
+		Attrs:     edge.Attrs.Copy(),
+		HTMLAttrs: edge.HTMLAttrs.Copy(),
+	})
+}
+
+// arcEdges adds edges between nodes of a graph, one for each distinct arc. The
+// attributes and ports of each edge are copied from the edge of the first arc
+// added between the same pair of nodes.
+type arcEdges struct {
+	g    *Graph
+	seen map[string]bool
+}
+
+// newArcEdges returns a new set of arc edges for g.
+func newArcEdges(g *Graph) *arcEdges {
+	return &arcEdges{g: g, seen: make(map[string]bool)}
+}
+
+// add adds an edge for the given arc to the graph, unless already present. The
+// reverse arc of an undirected edge is considered the same arc.
+func (a *arcEdges) add(arc Arc) {
+	src, dst := arc.Src.Name, arc.Dst.Name
+	if !arc.Edge.Dir && src > dst {
+		src, dst = dst, src
+	}
+	key := fmt.Sprintf("%q %v %q", src, arc.Edge.Dir, dst)
+	if a.seen[key] {
+		return
+	}
+	a.seen[key] = true
+	a.g.Edges.Add(nodeEdge(arc))
+}
+
+// nodeEdge returns a new edge between the nodes of the given arc, with the
+// attributes and node ports of its edge.
+func nodeEdge(arc Arc) *Edge {
+	edge := arc.Edge
+	e := &Edge{
+		Src:       arc.Src.Name,
+		Dst:       arc.Dst.Name,
+		Dir:       edge.Dir,
+		Attrs:     edge.Attrs.Copy(),
+		HTMLAttrs: edge.HTMLAttrs.Copy(),
+	}
+	// Retain the ports of node endpoints.
+	if e.Src == edge.Src {
+		e.SrcPort = edge.SrcPort
+	} else if e.Src == edge.Dst {
+		e.SrcPort = edge.DstPort
+	}
+	if e.Dst == edge.Dst {
+		e.DstPort = edge.DstPort
+	} else if e.Dst == edge.Src {
+		e.DstPort = edge.SrcPort
+	}
+	return e
+}
+
+// sortedKeys returns the keys of the given set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
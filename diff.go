@@ -0,0 +1,359 @@
+package dot
+
+// This file defines the difference between graphs.
+
+import "fmt"
+
+// ChangeKind specifies the kind of a change between two graphs.
+type ChangeKind int
+
+// Change kinds.
+const (
+	// Added is present in the new graph only.
+	Added ChangeKind = iota
+	// Removed is present in the old graph only.
+	Removed
+	// Changed is present in both graphs, but differs between them.
+	Changed
+)
+
+func (kind ChangeKind) String() string {
+	switch kind {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// AttrChange is a change to an attribute.
+type AttrChange struct {
+	Kind ChangeKind
+	Name string
+	// Old and New values of the attribute; empty if absent.
+	Old, New string
+}
+
+func (c AttrChange) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+%s=%s", c.Name, c.New)
+	case Removed:
+		return fmt.Sprintf("-%s=%s", c.Name, c.Old)
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Name, c.Old, c.New)
+}
+
+// diffAttrs returns the changes between the old and new attributes, in sorted
+// order of attribute names.
+func diffAttrs(old, new Attrs) []AttrChange {
+	var changes []AttrChange
+	for _, name := range old.SortedNames() {
+		value, ok := new[name]
+		switch {
+		case !ok:
+			changes = append(changes, AttrChange{Kind: Removed, Name: name, Old: old[name]})
+		case value != old[name]:
+			changes = append(changes, AttrChange{Kind: Changed, Name: name, Old: old[name], New: value})
+		}
+	}
+	for _, name := range new.SortedNames() {
+		if _, ok := old[name]; !ok {
+			changes = append(changes, AttrChange{Kind: Added, Name: name, New: new[name]})
+		}
+	}
+	return changes
+}
+
+// NodeChange is a change to a node present in both graphs.
+type NodeChange struct {
+	Old, New *Node
+	Attrs    []AttrChange
+}
+
+// EdgeChange is a change to an edge present in both graphs.
+type EdgeChange struct {
+	Old, New *Edge
+	Attrs    []AttrChange
+}
+
+// SubGraphChange is a change to a subgraph present in both graphs.
+type SubGraphChange struct {
+	Old, New *SubGraph
+	Attrs    []AttrChange
+	// Names of nodes added to and removed from the subgraph, in sorted order.
+	AddedNodes, RemovedNodes []string
+}
+
+// GraphDiff is the difference between an old and a new graph. Nodes and
+// subgraphs are matched by name, and edges by the names of their endpoints.
+type GraphDiff struct {
+	Old, New *Graph
+	// Changes to graph attributes.
+	Attrs []AttrChange
+	// Nodes present in one graph only; in node order of their graph.
+	AddedNodes, RemovedNodes []*Node
+	// Nodes with changed attributes; in node order of the new graph.
+	ChangedNodes []*NodeChange
+	// Edges present in one graph only; in edge order of their graph.
+	AddedEdges, RemovedEdges []*Edge
+	// Edges with changed attributes or ports; in edge order of the new graph.
+	ChangedEdges []*EdgeChange
+	// Subgraphs present in one graph only; in sorted order.
+	AddedSubGraphs, RemovedSubGraphs []*SubGraph
+	// Subgraphs with changed attributes or nodes; in sorted order.
+	ChangedSubGraphs []*SubGraphChange
+}
+
+// Diff returns the difference between the old graph a and the new graph b.
+func Diff(a, b *Graph) *GraphDiff {
+	d := &GraphDiff{Old: a, New: b}
+	d.Attrs = diffAttrs(a.Attrs, b.Attrs)
+
+	// Nodes.
+	for _, node := range a.Nodes.Nodes {
+		if _, ok := b.Nodes.Lookup[node.Name]; !ok {
+			d.RemovedNodes = append(d.RemovedNodes, node)
+		}
+	}
+	for _, node := range b.Nodes.Nodes {
+		old, ok := a.Nodes.Lookup[node.Name]
+		if !ok {
+			d.AddedNodes = append(d.AddedNodes, node)
+			continue
+		}
+		if attrs := diffAttrs(old.Attrs, node.Attrs); len(attrs) > 0 {
+			d.ChangedNodes = append(d.ChangedNodes, &NodeChange{Old: old, New: node, Attrs: attrs})
+		}
+	}
+
+	// Edges; parallel edges are matched in edge order.
+	olds := make(map[string][]*Edge)
+	for _, edge := range a.Edges.Edges {
+		key := edgeKey(edge)
+		olds[key] = append(olds[key], edge)
+	}
+	for _, edge := range b.Edges.Edges {
+		key := edgeKey(edge)
+		if len(olds[key]) == 0 {
+			d.AddedEdges = append(d.AddedEdges, edge)
+			continue
+		}
+		old := olds[key][0]
+		olds[key] = olds[key][1:]
+		attrs := diffAttrs(old.Attrs, edge.Attrs)
+		if len(attrs) > 0 || !samePorts(old, edge) {
+			d.ChangedEdges = append(d.ChangedEdges, &EdgeChange{Old: old, New: edge, Attrs: attrs})
+		}
+	}
+	for _, edge := range a.Edges.Edges {
+		key := edgeKey(edge)
+		if len(olds[key]) > 0 && olds[key][0] == edge {
+			d.RemovedEdges = append(d.RemovedEdges, edge)
+			olds[key] = olds[key][1:]
+		}
+	}
+
+	// Subgraphs.
+	for _, sub := range a.SubGraphs.Sorted() {
+		if !b.IsSubGraph(sub.Name) {
+			d.RemovedSubGraphs = append(d.RemovedSubGraphs, sub)
+		}
+	}
+	for _, sub := range b.SubGraphs.Sorted() {
+		old, ok := a.SubGraphs.SubGraphs[sub.Name]
+		if !ok {
+			d.AddedSubGraphs = append(d.AddedSubGraphs, sub)
+			continue
+		}
+		c := &SubGraphChange{Old: old, New: sub, Attrs: diffAttrs(old.Attrs, sub.Attrs)}
+		oldChildren := a.Relations.ParentToChildren[sub.Name]
+		newChildren := b.Relations.ParentToChildren[sub.Name]
+		for _, child := range b.Relations.SortedChildren(sub.Name) {
+			if !oldChildren[child] {
+				c.AddedNodes = append(c.AddedNodes, child)
+			}
+		}
+		for _, child := range a.Relations.SortedChildren(sub.Name) {
+			if !newChildren[child] {
+				c.RemovedNodes = append(c.RemovedNodes, child)
+			}
+		}
+		if len(c.Attrs) > 0 || len(c.AddedNodes) > 0 || len(c.RemovedNodes) > 0 {
+			d.ChangedSubGraphs = append(d.ChangedSubGraphs, c)
+		}
+	}
+	return d
+}
+
+// edgeKey returns the key used to match edges between graphs. The endpoints
+// of undirected edges are unordered.
+func edgeKey(edge *Edge) string {
+	src, dst := edge.Src, edge.Dst
+	if !edge.Dir && src > dst {
+		src, dst = dst, src
+	}
+	return fmt.Sprintf("%q %v %q", src, edge.Dir, dst)
+}
+
+// samePorts reports whether the edges have the same ports, with regards to
+// their endpoints.
+func samePorts(a, b *Edge) bool {
+	if a.Src == b.Src {
+		return a.SrcPort == b.SrcPort && a.DstPort == b.DstPort
+	}
+	// Undirected edge with swapped endpoints.
+	return a.SrcPort == b.DstPort && a.DstPort == b.SrcPort
+}
+
+// Empty reports whether the graphs are equal with regards to the diff.
+func (d *GraphDiff) Empty() bool {
+	return len(d.Attrs) == 0 &&
+		len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.ChangedNodes) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 && len(d.ChangedEdges) == 0 &&
+		len(d.AddedSubGraphs) == 0 && len(d.RemovedSubGraphs) == 0 && len(d.ChangedSubGraphs) == 0
+}
+
+// String returns a human-readable summary of the diff, with one change per
+// line.
+func (d *GraphDiff) String() string {
+	s := ""
+	for _, c := range d.Attrs {
+		s += fmt.Sprintf("~ graph %s\n", c)
+	}
+	for _, sub := range d.RemovedSubGraphs {
+		s += fmt.Sprintf("- subgraph %s\n", sub.Name)
+	}
+	for _, sub := range d.AddedSubGraphs {
+		s += fmt.Sprintf("+ subgraph %s\n", sub.Name)
+	}
+	for _, c := range d.ChangedSubGraphs {
+		for _, attr := range c.Attrs {
+			s += fmt.Sprintf("~ subgraph %s %s\n", c.New.Name, attr)
+		}
+		for _, name := range c.RemovedNodes {
+			s += fmt.Sprintf("~ subgraph %s -node %s\n", c.New.Name, name)
+		}
+		for _, name := range c.AddedNodes {
+			s += fmt.Sprintf("~ subgraph %s +node %s\n", c.New.Name, name)
+		}
+	}
+	for _, node := range d.RemovedNodes {
+		s += fmt.Sprintf("- node %s\n", node.Name)
+	}
+	for _, node := range d.AddedNodes {
+		s += fmt.Sprintf("+ node %s\n", node.Name)
+	}
+	for _, c := range d.ChangedNodes {
+		for _, attr := range c.Attrs {
+			s += fmt.Sprintf("~ node %s %s\n", c.New.Name, attr)
+		}
+	}
+	for _, edge := range d.RemovedEdges {
+		s += fmt.Sprintf("- edge %s\n", edgeString(edge))
+	}
+	for _, edge := range d.AddedEdges {
+		s += fmt.Sprintf("+ edge %s\n", edgeString(edge))
+	}
+	for _, c := range d.ChangedEdges {
+		if !samePorts(c.Old, c.New) {
+			s += fmt.Sprintf("~ edge %s -> %s\n", edgeString(c.Old), edgeString(c.New))
+		}
+		for _, attr := range c.Attrs {
+			s += fmt.Sprintf("~ edge %s %s\n", edgeString(c.New), attr)
+		}
+	}
+	return s
+}
+
+// edgeString returns a DOT-like representation of the endpoints of the edge.
+func edgeString(edge *Edge) string {
+	src, dst := edge.Src, edge.Dst
+	op := "--"
+	if edge.Dir {
+		op = "->"
+	}
+	return Quote(src) + quotePort(edge.SrcPort) + op + Quote(dst) + quotePort(edge.DstPort)
+}
+
+// Colours of the merged graph of a diff.
+const (
+	addedColor   = "green"
+	removedColor = "red"
+	changedColor = "orange"
+)
+
+// Merged returns a new graph containing the elements of both the old and the
+// new graph, with the attributes of the new graph. Added, removed and changed
+// nodes, edges and subgraphs are coloured green, red and orange respectively,
+// and removed elements are dashed.
+func (d *GraphDiff) Merged() *Graph {
+	g := copyGraph(d.New, nil)
+	for _, edge := range d.New.Edges.Edges {
+		copyEdge(g, edge)
+	}
+	// Re-add removed elements, mapping the root graph of the old graph to the
+	// new one.
+	parent := func(name string) string {
+		if name == d.Old.Name {
+			return d.New.Name
+		}
+		return name
+	}
+	for _, sub := range d.RemovedSubGraphs {
+		g.SubGraphs.Add(sub.Name)
+		s := g.SubGraphs.SubGraphs[sub.Name]
+		s.Attrs = sub.Attrs.Copy()
+		s.HTMLAttrs = sub.HTMLAttrs.Copy()
+		s.Parent = parent(sub.Parent)
+		markRemoved(s.Attrs)
+	}
+	for _, node := range d.RemovedNodes {
+		n := &Node{Name: node.Name, Attrs: node.Attrs.Copy(), HTMLAttrs: node.HTMLAttrs.Copy()}
+		markRemoved(n.Attrs)
+		g.Nodes.Add(n)
+		for _, p := range sortedKeys(d.Old.Relations.ChildToParents[node.Name]) {
+			g.Relations.Add(parent(p), node.Name)
+		}
+	}
+	for _, edge := range d.RemovedEdges {
+		copyEdge(g, edge)
+		markRemoved(g.Edges.Edges[len(g.Edges.Edges)-1].Attrs)
+	}
+
+	// Colour added and changed elements.
+	for _, sub := range d.AddedSubGraphs {
+		g.SubGraphs.SubGraphs[sub.Name].Attrs["color"] = addedColor
+	}
+	for _, c := range d.ChangedSubGraphs {
+		g.SubGraphs.SubGraphs[c.New.Name].Attrs["color"] = changedColor
+	}
+	for _, node := range d.AddedNodes {
+		g.Nodes.Lookup[node.Name].Attrs["color"] = addedColor
+	}
+	for _, c := range d.ChangedNodes {
+		g.Nodes.Lookup[c.New.Name].Attrs["color"] = changedColor
+	}
+	// The edges of the merged graph are in edge order of the new graph.
+	index := make(map[*Edge]int)
+	for i, edge := range d.New.Edges.Edges {
+		index[edge] = i
+	}
+	for _, edge := range d.AddedEdges {
+		g.Edges.Edges[index[edge]].Attrs["color"] = addedColor
+	}
+	for _, c := range d.ChangedEdges {
+		g.Edges.Edges[index[c.New]].Attrs["color"] = changedColor
+	}
+	linkNodes(g)
+	return g
+}
+
+// markRemoved marks the attributes of a removed element.
+func markRemoved(attrs Attrs) {
+	attrs["color"] = removedColor
+	attrs["style"] = "dashed"
+}
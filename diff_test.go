@@ -0,0 +1,58 @@
+package dot
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	a, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_0 { a; b; }
+		a -> b [label=x];
+		b -> c;
+		c -> d;
+		d [shape=box];
+	}`))
+	check(t, err)
+	b, err := Read([]byte(`digraph G {
+		subgraph cluster_0 { a; b; e; }
+		b -> c;
+		a -> b [label=y];
+		c -> e;
+		d [shape=circle];
+		c [color=blue];
+	}`))
+	check(t, err)
+	d := Diff(a, b)
+	want := `~ graph -rankdir=LR
+~ subgraph cluster_0 +node e
++ node e
+~ node c +color=blue
+~ node d shape: box -> circle
+- edge c->d
++ edge c->e
+~ edge a->b label: x -> y
+`
+	assert(t, "diff", d.String(), want)
+	assert(t, "empty", d.Empty(), false)
+	assert(t, "empty", Diff(a, a).Empty(), true)
+
+	m := d.Merged()
+	assert(t, "removed edge", m.Edges.SrcToDsts["c"]["d"].Attrs["color"], removedColor)
+	assert(t, "removed edge", m.Edges.SrcToDsts["c"]["d"].Attrs["style"], "dashed")
+	assert(t, "added edge", m.Edges.SrcToDsts["c"]["e"].Attrs["color"], addedColor)
+	assert(t, "changed edge", m.Edges.SrcToDsts["a"]["b"].Attrs["color"], changedColor)
+	assert(t, "added node", m.Nodes.Lookup["e"].Attrs["color"], addedColor)
+	assert(t, "changed node", m.Nodes.Lookup["d"].Attrs["color"], changedColor)
+	assert(t, "unchanged node", m.Nodes.Lookup["a"].Attrs["color"], "")
+	assert(t, "changed subgraph", m.SubGraphs.SubGraphs["cluster_0"].Attrs["color"], changedColor)
+	// The input graphs are left unmodified.
+	assert(t, "new edge", b.Edges.SrcToDsts["c"]["e"].Attrs["color"], "")
+
+	// Removed subgraphs retain their nesting.
+	a, err = Read([]byte(`digraph A { subgraph cluster_a { subgraph cluster_b { x } } }`))
+	check(t, err)
+	b, err = Read([]byte(`digraph B { x }`))
+	check(t, err)
+	m = Diff(a, b).Merged()
+	assert(t, "removed outer parent", m.SubGraphs.SubGraphs["cluster_a"].Parent, "B")
+	assert(t, "removed inner parent", m.SubGraphs.SubGraphs["cluster_b"].Parent, "cluster_a")
+}
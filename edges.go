@@ -20,12 +20,13 @@ import (
 
 //Represents an Edge.
 type Edge struct {
-	Src     string
-	SrcPort string
-	Dst     string
-	DstPort string
-	Dir     bool
-	Attrs   Attrs
+	Src       string
+	SrcPort   string
+	Dst       string
+	DstPort   string
+	Dir       bool
+	Attrs     Attrs
+	HTMLAttrs HTMLAttrs
 }
 
 //Represents a set of Edges.
@@ -86,6 +87,36 @@ func (edges *Edges) del(edge *Edge) {
 	edges.Edges = es
 }
 
+// Reverse reverses the direction of the edge in place, swapping its source and
+// destination along with their ports. The position of the edge within the
+// list of edges is retained.
+func (edges *Edges) Reverse(edge *Edge) {
+	// Remove source to destination edge.
+	if dsts, ok := edges.SrcToDsts[edge.Src]; ok && dsts[edge.Dst] == edge {
+		delete(dsts, edge.Dst)
+	}
+	// Remove destination to source edge.
+	if srcs, ok := edges.DstToSrcs[edge.Dst]; ok && srcs[edge.Src] == edge {
+		delete(srcs, edge.Src)
+	}
+
+	edge.Src, edge.Dst = edge.Dst, edge.Src
+	edge.SrcPort, edge.DstPort = edge.DstPort, edge.SrcPort
+
+	if _, ok := edges.SrcToDsts[edge.Src]; !ok {
+		edges.SrcToDsts[edge.Src] = make(map[string]*Edge)
+	}
+	if _, ok := edges.SrcToDsts[edge.Src][edge.Dst]; !ok {
+		edges.SrcToDsts[edge.Src][edge.Dst] = edge
+	}
+	if _, ok := edges.DstToSrcs[edge.Dst]; !ok {
+		edges.DstToSrcs[edge.Dst] = make(map[string]*Edge)
+	}
+	if _, ok := edges.DstToSrcs[edge.Dst][edge.Src]; !ok {
+		edges.DstToSrcs[edge.Dst][edge.Src] = edge
+	}
+}
+
 //Returns a sorted list of Edges.
 func (this *Edges) Sorted() []*Edge {
 	srcs := make([]string, 0, len(this.SrcToDsts))
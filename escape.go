@@ -17,13 +17,12 @@ package dot
 import (
 	"fmt"
 	"strings"
-	"text/template"
 	"unicode"
-
-	"github.com/mewspring/dot/scanner"
-	"github.com/mewspring/dot/token"
 )
 
+// Escape is a graph which previously quoted the names and attributes added to
+// it when required. As graphs now store unquoted values, which are quoted when
+// written, it behaves the same as Graph.
 type Escape struct {
 	*Graph
 }
@@ -33,27 +32,32 @@ func NewEscape() *Escape {
 	return &Escape{NewGraph()}
 }
 
+// isHtml reports whether s is a DOT HTML string enclosing well-formed markup.
+// The markup is not validated against the HTML-like label grammar, as quoting
+// an invalid label would change its meaning.
 func isHtml(s string) bool {
-	if len(s) == 0 {
-		return false
-	}
 	ss := strings.TrimSpace(s)
-	if ss[0] != '<' {
+	if len(ss) < 2 || ss[0] != '<' || ss[len(ss)-1] != '>' {
 		return false
 	}
-	count := 0
-	for _, c := range ss {
-		if c == '<' {
-			count += 1
-		}
-		if c == '>' {
-			count -= 1
+	// The enclosing angle brackets must match.
+	depth := 0
+	for i, c := range ss {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 && i != len(ss)-1 {
+				return false
+			}
 		}
 	}
-	if count == 0 {
-		return true
+	if depth != 0 {
+		return false
 	}
-	return false
+	_, err := (&htmlParser{s: ss[1 : len(ss)-1]}).parse()
+	return err == nil
 }
 
 func isLetter(ch rune) bool {
@@ -61,126 +65,193 @@ func isLetter(ch rune) bool {
 		ch >= 0x80 && unicode.IsLetter(ch) && ch != 'ε'
 }
 
-func isId(s string) bool {
-	i := 0
-	pos := false
-	for _, c := range s {
-		if i == 0 {
-			if !isLetter(c) {
-				return false
-			}
-			pos = true
-		}
-		if unicode.IsSpace(c) {
-			return false
-		}
-		if c == '-' {
-			return false
-		}
-		i++
-	}
-	return pos
-}
-
 func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9' || ch >= 0x80 && unicode.IsDigit(ch)
 }
 
-func isNumber(s string) bool {
-	state := 0
-	for _, c := range s {
-		if state == 0 {
-			if isDigit(c) || c == '.' {
-				state = 2
-			} else if c == '-' {
-				state = 1
-			} else {
-				return false
-			}
-		} else if state == 1 {
-			if isDigit(c) || c == '.' {
-				state = 2
-			}
-		} else if c != '.' && !isDigit(c) {
-			return false
-		}
-	}
-	return (state == 2)
-}
-
-func isStringLit(s string) bool {
-	lex := &scanner.Scanner{}
-	lex.Init([]byte(s), token.DOTTokens)
-	tok, _ := lex.Scan()
-	if tok.Type != token.DOTTokens.Type("string_lit") {
+// isBareId reports whether s is a valid unquoted DOT identifier; i.e. an
+// alphanumeric string not beginning with a digit, or a numeral, which is not
+// a keyword.
+func isBareId(s string) bool {
+	if len(s) == 0 {
 		return false
 	}
-	tok, _ = lex.Scan()
-	if tok.Type != token.EOF {
+	switch strings.ToLower(s) {
+	case "node", "edge", "graph", "digraph", "subgraph", "strict":
 		return false
 	}
+	if isNumeral(s) {
+		return true
+	}
+	for i, c := range s {
+		if !isLetter(c) && (i == 0 || !isDigit(c)) {
+			return false
+		}
+	}
 	return true
 }
 
-func esc(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	if isHtml(s) {
-		return s
+// isNumeral reports whether s is a DOT numeral, [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?).
+func isNumeral(s string) bool {
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
 	}
-	ss := strings.TrimSpace(s)
-	if ss[0] == '<' {
-		return fmt.Sprintf("\"%s\"", strings.Replace(s, "\"", "\\\"", -1))
-	}
-	if isId(s) {
-		return s
+	digits, dot := 0, false
+	for _, c := range s {
+		switch {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
 	}
-	if isNumber(s) {
+	return digits > 0
+}
+
+// Quote returns s as a DOT ID, quoting it only if required; i.e. unless s is
+// an alphanumeric string not beginning with a digit, or a numeral. Double
+// quotes within quoted strings are escaped, and other characters are retained,
+// as backslashes are interpreted by Graphviz rather than DOT. A backslash which
+// would otherwise escape a double quote or the closing quote is escaped itself.
+//
+// Values of HTML strings, as recorded by HTMLAttrs, are not quoted; see
+// quoteValue.
+func Quote(s string) string {
+	if isBareId(s) {
 		return s
 	}
-	if isStringLit(s) {
-		return s
+	buf := &strings.Builder{}
+	buf.WriteByte('"')
+	// Length of the current run of backslashes.
+	n := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == '"' {
+			if n%2 == 1 {
+				buf.WriteByte('\\')
+			}
+			if i == len(s) {
+				break
+			}
+			buf.WriteString(`\"`)
+			n = 0
+			continue
+		}
+		if s[i] == '\\' {
+			n++
+		} else {
+			n = 0
+		}
+		buf.WriteByte(s[i])
 	}
-	return fmt.Sprintf("\"%s\"", template.HTMLEscapeString(s))
+	buf.WriteByte('"')
+	return buf.String()
 }
 
-func escAttrs(attrs map[string]string) map[string]string {
-	newAttrs := make(map[string]string)
-	for k, v := range attrs {
-		newAttrs[esc(k)] = esc(v)
+// quoteValue returns the given attribute value as a DOT ID, which is the value
+// itself for HTML strings, and quoted if required otherwise.
+func quoteValue(value string, html bool) string {
+	if html {
+		return value
 	}
-	return newAttrs
-}
-
-func (this *Escape) SetName(name string) {
-	this.Graph.SetName(esc(name))
+	return Quote(value)
 }
 
-func (this *Escape) AddPortEdge(src, srcPort, dst, dstPort string, directed bool, attrs map[string]string) {
-	this.Graph.AddPortEdge(esc(src), srcPort, esc(dst), dstPort, directed, escAttrs(attrs))
-}
-
-func (this *Escape) AddEdge(src, dst string, directed bool, attrs map[string]string) {
-	this.AddPortEdge(src, "", dst, "", directed, attrs)
+// Unquote returns the value of the given DOT ID. Quoted strings are stripped of
+// their quotes, escaped newlines and the backslashes of escaped double quotes;
+// other escape sequences, e.g. `\n`, are retained. HTML strings, including
+// their angle brackets, and other IDs are returned unchanged.
+func Unquote(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		buf := &strings.Builder{}
+		for i := 1; i < len(s); i++ {
+			switch c := s[i]; c {
+			case '"':
+				if i != len(s)-1 {
+					return "", fmt.Errorf("invalid quoted string %q; unescaped quote at offset %d", s, i)
+				}
+				return buf.String(), nil
+			case '\\':
+				switch next := s[i+1:]; {
+				case strings.HasPrefix(next, "\n"):
+					// Escaped newlines are removed.
+					i++
+				case strings.HasPrefix(next, "\r\n"):
+					i += 2
+				case strings.HasPrefix(next, `"`):
+					buf.WriteByte('"')
+					i++
+				case strings.HasPrefix(next, `\`):
+					// Escaped backslashes are retained, and do not escape a
+					// following quote.
+					buf.WriteString(`\\`)
+					i++
+				default:
+					buf.WriteByte(c)
+				}
+			default:
+				buf.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("invalid quoted string %q; missing closing quote", s)
+	case strings.HasPrefix(s, "<"):
+		if !strings.HasSuffix(s, ">") {
+			return "", fmt.Errorf("invalid HTML string %q; missing closing angle bracket", s)
+		}
+		return s, nil
+	case !isBareId(s):
+		return "", fmt.Errorf("invalid ID %q", s)
+	}
+	return s, nil
 }
 
-func (this *Escape) AddNode(parentGraph string, name string, attrs map[string]string) {
-	this.Graph.AddNode(esc(parentGraph), esc(name), escAttrs(attrs))
+// isHtmlId reports whether the given DOT ID is an HTML string.
+func isHtmlId(id string) bool {
+	return strings.HasPrefix(id, "<")
 }
 
-func (this *Escape) AddAttr(parentGraph string, field, value string) {
-	this.Graph.AddAttr(esc(parentGraph), esc(field), esc(value))
+// unquoteId returns the value of the given DOT ID, or the ID itself if
+// invalid.
+func unquoteId(s string) string {
+	if value, err := Unquote(s); err == nil {
+		return value
+	}
+	return s
 }
 
-func (this *Escape) AddSubGraph(parentGraph string, name string, attrs map[string]string) {
-	this.Graph.AddSubGraph(esc(parentGraph), esc(name), escAttrs(attrs))
+// splitPort splits the given port of the form ":id" or ":id:compass_pt" into
+// its ID and compass point, if any.
+func splitPort(port string) (id, compass string) {
+	port = strings.TrimPrefix(port, ":")
+	if i := strings.LastIndex(port, ":"); i != -1 && contains(compassPoints, port[i+1:]) {
+		return port[:i], port[i+1:]
+	}
+	return port, ""
 }
 
-func (this *Escape) IsNode(name string) bool {
-	return this.Graph.IsNode(esc(name))
+// joinPort returns the port of the given ID and compass point, which may be
+// empty.
+func joinPort(id, compass string) string {
+	if id == "" {
+		return ""
+	}
+	if compass == "" {
+		return ":" + id
+	}
+	return ":" + id + ":" + compass
 }
 
-func (this *Escape) IsSubGraph(name string) bool {
-	return this.Graph.IsSubGraph(esc(name))
+// quotePort returns the given port with its ID and compass point quoted if
+// required.
+func quotePort(port string) string {
+	if port == "" {
+		return ""
+	}
+	id, compass := splitPort(port)
+	if compass == "" {
+		return ":" + Quote(id)
+	}
+	return ":" + Quote(id) + ":" + compass
 }
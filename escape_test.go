@@ -33,10 +33,10 @@ func TestEscape(t *testing.T) {
 	g.AddEdge("kasdf99 99", "7", true, nil)
 	s := g.String()
 	if !strings.HasPrefix(s, `digraph "asdf adsf" {
-	"kasdf99 99"->7;
-	"a &lt;&lt; b";
-	"kasdf99 99" [ "<asfd"=1 ];
+	"a << b";
 	7 [ "<asfd"=1 ];
+	"kasdf99 99" [ "<asfd"=1 ];
+	"kasdf99 99"->7;
 
 }`) {
 		t.Fatalf("%s", s)
@@ -45,3 +45,93 @@ func TestEscape(t *testing.T) {
 		t.Fatalf("should be a node")
 	}
 }
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		value, id string
+	}{
+		{"a_1", "a_1"},
+		{"-.5", "-.5"},
+		{"", `""`},
+		{"node", `"node"`},
+		{"1a", `"1a"`},
+		{"Tom & Jerry's", `"Tom & Jerry's"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`a\nb`, `"a\nb"`},
+		{"<<b>bold</b>>", `"<<b>bold</b>>"`},
+		{`a\\"b\\`, `"a\\\"b\\"`},
+		{"<f0> a|<f1> b", `"<f0> a|<f1> b"`},
+	}
+	for _, test := range tests {
+		assert(t, test.value, Quote(test.value), test.id)
+		value, err := Unquote(test.id)
+		check(t, err)
+		assert(t, test.id, value, test.value)
+	}
+	// Backslashes escaping a double quote or the closing quote are escaped.
+	for _, s := range []string{`C:\`, `a\"b`} {
+		value, err := Unquote(Quote(s))
+		check(t, err)
+		assert(t, s, Quote(value), Quote(s))
+	}
+	assert(t, "trailing backslash", Quote(`C:\`), `"C:\\"`)
+	assert(t, "HTML value", quoteValue("<<b>bold</b>>", true), "<<b>bold</b>>")
+	value, err := Unquote("\"multi\\\nline \\\\\"")
+	check(t, err)
+	assert(t, "escaped newline and backslash", value, `multiline \\`)
+	for _, id := range []string{`"abc`, `"a"b"`, "<a", "a b"} {
+		if _, err := Unquote(id); err == nil {
+			t.Errorf("%q: expected error", id)
+		}
+	}
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	g, err := Read([]byte(`digraph "my graph" {
+		"Tom & Jerry's" [label="say \"hi\" & 'bye'", tooltip=<<b>&amp;</b>>];
+		"Tom & Jerry's":"in port":n -> b [label="a\lb"];
+		b [label="<b>", xlabel=<<b>b</b>>];
+	}`))
+	check(t, err)
+	assert(t, "graph name", g.Name, "my graph")
+	node := g.Nodes.Lookup["Tom & Jerry's"]
+	assert(t, "label", node.Attrs["label"], `say "hi" & 'bye'`)
+	assert(t, "HTML", node.Attrs["tooltip"], "<<b>&amp;</b>>")
+	assert(t, "HTML attribute", node.HTMLAttrs["tooltip"], true)
+	assert(t, "quoted HTML", g.Nodes.Lookup["b"].Attrs["label"], "<b>")
+	assert(t, "not HTML", g.Nodes.Lookup["b"].HTMLAttrs["label"], false)
+	edge := g.Edges.Edges[0]
+	assert(t, "port", edge.SrcPort, ":in port:n")
+	assert(t, "escString", edge.Attrs["label"], `a\lb`)
+	for i := 0; i < 2; i++ {
+		s := g.String()
+		g, err = Read([]byte(s))
+		check(t, err)
+		assert(t, "round trip", g.String(), s)
+	}
+	assert(t, "label after round trip", g.Nodes.Lookup["Tom & Jerry's"].Attrs["label"], `say "hi" & 'bye'`)
+	assert(t, "port after round trip", g.Edges.Edges[0].SrcPort, ":in port:n")
+	assert(t, "quoted HTML after round trip", g.Nodes.Lookup["b"].HTMLAttrs["label"], false)
+	assert(t, "HTML after round trip", g.Nodes.Lookup["b"].HTMLAttrs["xlabel"], true)
+}
+
+func TestHTMLAttrs(t *testing.T) {
+	g, err := Read([]byte(`digraph {
+		node [label=<<i>n</i>>];
+		a;
+		b [label="<i>b</i>"];
+		subgraph s { label=<<b>s</b>>; c }
+		a -> b [label=<e>];
+	}`))
+	check(t, err)
+	assert(t, "default attribute", g.Nodes.Lookup["a"].HTMLAttrs["label"], true)
+	assert(t, "quoted attribute", g.Nodes.Lookup["b"].HTMLAttrs["label"], false)
+	assert(t, "subgraph attribute", g.SubGraphs.SubGraphs["s"].HTMLAttrs["label"], true)
+	assert(t, "edge attribute", g.Edges.Edges[0].HTMLAttrs["label"], true)
+	c := g.Clone()
+	assert(t, "cloned", c.String(), g.String())
+	// Attributes set by value are not HTML strings.
+	c.AddAttr("s", "label", "<b>s</b>")
+	assert(t, "replaced attribute", c.SubGraphs.SubGraphs["s"].HTMLAttrs["label"], false)
+	assert(t, "original attribute", g.SubGraphs.SubGraphs["s"].HTMLAttrs["label"], true)
+}
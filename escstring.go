@@ -0,0 +1,171 @@
+package dot
+
+// This file defines the expansion of escString attributes, e.g. labels and
+// tooltips, into the display text of Graphviz, as documented at
+// https://graphviz.org/docs/attr-types/escString/
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Justify specifies the justification of a line of display text.
+type Justify int
+
+// Justifications of lines of display text.
+const (
+	// JustifyCenter centers the line; terminated by "\n".
+	JustifyCenter Justify = iota
+	// JustifyLeft left-justifies the line; terminated by "\l".
+	JustifyLeft
+	// JustifyRight right-justifies the line; terminated by "\r".
+	JustifyRight
+)
+
+// String returns the escape sequence terminating lines of the justification.
+func (j Justify) String() string {
+	switch j {
+	case JustifyLeft:
+		return `\l`
+	case JustifyRight:
+		return `\r`
+	}
+	return `\n`
+}
+
+// TextLine is a line of display text.
+type TextLine struct {
+	Text    string
+	Justify Justify
+}
+
+// DisplayText is the display text of an escString attribute, split into lines.
+type DisplayText []TextLine
+
+// String returns the lines of the display text separated by newlines.
+func (t DisplayText) String() string {
+	lines := make([]string, len(t))
+	for i, line := range t {
+		lines[i] = line.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ExpandText returns the display text of the named attribute of elem, which is
+// the graph g or one of its subgraphs, nodes or edges. The escape sequences
+// \G, \N, \E, \T, \H and \L are replaced by the names of the graph, node,
+// edge, tail and head, and the label of elem, respectively, and the text is
+// split into lines at \n, \l and \r. The default label of nodes is \N.
+func ExpandText(g *Graph, elem interface{}, name string) (DisplayText, error) {
+	value, html, err := rawText(elem, name)
+	if err != nil {
+		return nil, err
+	}
+	if html {
+		return nil, fmt.Errorf("unable to expand HTML-like label of attribute %q", name)
+	}
+	names := escNames(g, elem)
+	if name != "label" {
+		// Retain \L when expanding the label, to avoid recursion.
+		label, html, err := rawText(elem, "label")
+		if err != nil {
+			return nil, err
+		}
+		if !html {
+			names['L'] = substEsc(label, names)
+		}
+	}
+	return splitLines(substEsc(value, names)), nil
+}
+
+// rawText returns the unexpanded value of the named attribute of elem, and
+// whether it is an HTML string.
+func rawText(elem interface{}, name string) (string, bool, error) {
+	switch elem := elem.(type) {
+	case *Graph:
+		return elem.Attr(name), elem.HTMLAttrs[name], nil
+	case *SubGraph:
+		return elem.Attr(name), elem.HTMLAttrs[name], nil
+	case *Node:
+		return elem.Attr(name), elem.HTMLAttrs[name], nil
+	case *Edge:
+		return elem.Attr(name), elem.HTMLAttrs[name], nil
+	}
+	return "", false, fmt.Errorf("unable to expand attribute %q of %T; expected graph, subgraph, node or edge", name, elem)
+}
+
+// escNames returns the replacements of the escape sequences of elem, keyed by
+// the character following the backslash.
+func escNames(g *Graph, elem interface{}) map[byte]string {
+	names := map[byte]string{'G': g.Name}
+	switch elem := elem.(type) {
+	case *SubGraph:
+		names['G'] = elem.Name
+	case *Node:
+		names['N'] = elem.Name
+	case *Edge:
+		op := "--"
+		if elem.Dir {
+			op = "->"
+		}
+		names['T'] = elem.Src
+		names['H'] = elem.Dst
+		names['E'] = elem.Src + elem.SrcPort + op + elem.Dst + elem.DstPort
+	}
+	return names
+}
+
+// substEsc replaces the escape sequences of s present in names. Other escape
+// sequences are retained.
+func substEsc(s string, names map[byte]string) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			if name, ok := names[s[i+1]]; ok {
+				buf.WriteString(name)
+			} else {
+				buf.WriteString(s[i : i+2])
+			}
+			i++
+			continue
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}
+
+// splitLines splits s into lines terminated by \n, \l, \r or newline
+// characters, and replaces other escape sequences by the escaped character. A
+// trailing line without terminator is centered.
+func splitLines(s string) DisplayText {
+	var lines DisplayText
+	line := &strings.Builder{}
+	end := func(justify Justify) {
+		lines = append(lines, TextLine{Text: line.String(), Justify: justify})
+		line.Reset()
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\n':
+			end(JustifyCenter)
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				end(JustifyCenter)
+			case 'l':
+				end(JustifyLeft)
+			case 'r':
+				end(JustifyRight)
+			default:
+				line.WriteByte(s[i])
+			}
+		default:
+			line.WriteByte(c)
+		}
+	}
+	if line.Len() > 0 || len(lines) == 0 {
+		end(JustifyCenter)
+	}
+	return lines
+}
@@ -0,0 +1,54 @@
+package dot
+
+import "testing"
+
+func TestExpandText(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		label="graph \G\lof \N\r";
+		subgraph cluster_x { label="cluster \G"; c; }
+		a [tooltip="node \N in \G: \L"];
+		b [label="left\lright\rcenter\nlast \{x\}"];
+		"quoted \"name\"";
+		h [label=<<b>\N</b>>];
+		a:p:s -> b [label="\E", tooltip="\T to \H (\L)"];
+	}`))
+	check(t, err)
+	expand := func(elem interface{}, name string) DisplayText {
+		text, err := ExpandText(g, elem, name)
+		check(t, err)
+		return text
+	}
+
+	// justified returns the text with each line followed by its justification.
+	justified := func(text DisplayText) string {
+		s := ""
+		for _, line := range text {
+			s += line.Text + line.Justify.String()
+		}
+		return s
+	}
+
+	// \N is not defined for graphs, and is displayed as N.
+	assert(t, "graph label", justified(expand(g, "label")), `graph G\lof N\r`)
+	assert(t, "cluster label", expand(g.SubGraphs.SubGraphs["cluster_x"], "label").String(), "cluster cluster_x")
+
+	a, b := g.Nodes.Lookup["a"], g.Nodes.Lookup["b"]
+	assert(t, "default label", expand(a, "label").String(), "a")
+	assert(t, "node tooltip", expand(a, "tooltip").String(), "node a in G: a")
+	text := expand(b, "label")
+	assert(t, "justified", justified(text), `left\lright\rcenter\nlast {x}\n`)
+	assert(t, "string", text.String(), "left\nright\ncenter\nlast {x}")
+	assert(t, "quoted name", expand(g.Nodes.Lookup[`quoted "name"`], "label").String(), `quoted "name"`)
+
+	edge := g.Edges.Edges[0]
+	assert(t, "edge label", expand(edge, "label").String(), "a:p:s->b")
+	assert(t, "edge tooltip", expand(edge, "tooltip").String(), "a to b (a:p:s->b)")
+	assert(t, "empty", justified(expand(edge, "xlabel")), `\n`)
+
+	if _, err := ExpandText(g, g.Nodes.Lookup["h"], "label"); err == nil {
+		t.Errorf("expected error for HTML-like label")
+	}
+	if _, err := ExpandText(g, "a", "label"); err == nil {
+		t.Errorf("expected error for invalid element")
+	}
+}
@@ -0,0 +1,99 @@
+package dot
+
+// This file defines the extraction of subgraphs from graphs.
+
+// InducedSubgraph returns a new graph containing the given nodes of g and the
+// edges between them, along with the subgraphs containing the nodes. The name,
+// type and attributes of g are retained.
+//
+// Edges to or from subgraphs with only some nodes among the given ones are
+// replaced by one edge per pair of given nodes.
+func InducedSubgraph(g *Graph, nodes []*Node) *Graph {
+	keep := make([]bool, len(g.Nodes.Nodes))
+	for _, node := range nodes {
+		keep[node.Index] = true
+	}
+	s := copyGraph(g, func(node *Node) bool {
+		return keep[node.Index]
+	})
+	for _, edge := range g.Edges.Edges {
+		var arcs []Arc
+		all := true
+		for _, src := range g.endpoints(edge.Src) {
+			for _, dst := range g.endpoints(edge.Dst) {
+				if keep[src.Index] && keep[dst.Index] {
+					arcs = append(arcs, Arc{Src: src, Dst: dst, Edge: edge})
+				} else {
+					all = false
+				}
+			}
+		}
+		switch {
+		case len(arcs) == 0:
+			continue
+		case all:
+			copyEdge(s, edge)
+		default:
+			for _, arc := range arcs {
+				s.Edges.Add(nodeEdge(arc))
+			}
+		}
+	}
+	linkNodes(s)
+	return s
+}
+
+// Direction specifies which arcs to follow when exploring the neighbourhood of
+// a node.
+type Direction int
+
+// Directions.
+const (
+	// Descendants follows outgoing arcs.
+	Descendants Direction = 1 << iota
+	// Ancestors follows incoming arcs.
+	Ancestors
+	// Both follows both outgoing and incoming arcs.
+	Both = Descendants | Ancestors
+)
+
+// Within returns the nodes at most k arcs away from node in the given
+// direction, including node itself, in order of distance. A negative k places
+// no limit on the distance.
+func (g *Graph) Within(node *Node, k int, dir Direction) []*Node {
+	adj := make([][]*Node, len(g.Nodes.Nodes))
+	for _, arc := range g.Arcs() {
+		if dir&Descendants != 0 {
+			adj[arc.Src.Index] = append(adj[arc.Src.Index], arc.Dst)
+		}
+		if dir&Ancestors != 0 {
+			adj[arc.Dst.Index] = append(adj[arc.Dst.Index], arc.Src)
+		}
+	}
+	dist := make([]int, len(g.Nodes.Nodes))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[node.Index] = 0
+	nodes := []*Node{node}
+	for i := 0; i < len(nodes); i++ {
+		v := nodes[i]
+		if k >= 0 && dist[v.Index] >= k {
+			continue
+		}
+		for _, w := range adj[v.Index] {
+			if dist[w.Index] == -1 {
+				dist[w.Index] = dist[v.Index] + 1
+				nodes = append(nodes, w)
+			}
+		}
+	}
+	return nodes
+}
+
+// Neighbourhood returns a new graph induced by the nodes at most k arcs away
+// from node in the given direction, including node itself. A negative k
+// places no limit on the distance.
+func Neighbourhood(g *Graph, node *Node, k int, dir Direction) *Graph {
+	return InducedSubgraph(g, g.Within(node, k, dir))
+}
@@ -0,0 +1,52 @@
+package dot
+
+import "testing"
+
+const services = `digraph G {
+	subgraph cluster_edge { gateway; }
+	client -> gateway -> "auth-service" -> db;
+	"auth-service" -> cache [label=lookup];
+	billing -> "auth-service";
+	billing -> ledger;
+	gateway -> {billing ledger};
+}`
+
+func TestNeighbourhood(t *testing.T) {
+	g, err := Read([]byte(services))
+	check(t, err)
+	auth := g.Nodes.Lookup["auth-service"]
+
+	n := Neighbourhood(g, auth, 1, Both)
+	assert(t, "nodes", nodeNames(n.Nodes.Nodes), `[gateway auth-service db cache billing]`)
+	assert(t, "edges", edgeNames(n), `[gateway->auth-service auth-service->db auth-service->cache billing->auth-service gateway->billing]`)
+	assert(t, "edge attribute", n.Edges.SrcToDsts["auth-service"]["cache"].Attrs["label"], "lookup")
+	assert(t, "subgraph membership", n.Relations.ParentToChildren["cluster_edge"]["gateway"], true)
+
+	n = Neighbourhood(g, auth, 2, Ancestors)
+	assert(t, "nodes", nodeNames(n.Nodes.Nodes), `[gateway client auth-service billing]`)
+
+	n = Neighbourhood(g, auth, -1, Descendants)
+	assert(t, "nodes", nodeNames(n.Nodes.Nodes), `[auth-service db cache]`)
+	assert(t, "subgraph", n.IsSubGraph("cluster_edge"), false)
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g, err := Read([]byte(services))
+	check(t, err)
+	var nodes []*Node
+	for _, name := range []string{"gateway", "billing", "ledger"} {
+		nodes = append(nodes, g.Nodes.Lookup[name])
+	}
+	s := InducedSubgraph(g, nodes)
+	// The edge to the anonymous subgraph {billing ledger} is retained.
+	assert(t, "number of edges", len(s.Edges.Edges), 2)
+	assert(t, "successors", nodeNames(s.Nodes.Lookup["gateway"].Succs), "[billing ledger]")
+
+	// Subgraphs retain their nesting, along with enclosing subgraphs without
+	// nodes of their own.
+	g, err = Read([]byte(`digraph G { subgraph cluster_a { subgraph cluster_b { y } } z }`))
+	check(t, err)
+	s = InducedSubgraph(g, []*Node{g.Nodes.Lookup["y"]})
+	assert(t, "outer parent", s.SubGraphs.SubGraphs["cluster_a"].Parent, "G")
+	assert(t, "inner parent", s.SubGraphs.SubGraphs["cluster_b"].Parent, "cluster_a")
+}
@@ -19,6 +19,7 @@ import "fmt"
 //The analysed representation of the Graph parsed from the DOT format.
 type Graph struct {
 	Attrs     Attrs
+	HTMLAttrs HTMLAttrs
 	Name      string
 	Directed  bool
 	Strict    bool
@@ -42,6 +43,92 @@ func NewGraph() *Graph {
 	}
 }
 
+// Clone returns a deep copy of the graph, which shares no state with the
+// original. The order of nodes and edges, the predecessors and successors of
+// each node, and the dominator tree are retained.
+func (g *Graph) Clone() *Graph {
+	c := &Graph{
+		Attrs:     g.Attrs.Copy(),
+		HTMLAttrs: g.HTMLAttrs.Copy(),
+		Name:      g.Name,
+		Directed:  g.Directed,
+		Strict:    g.Strict,
+		Nodes:     NewNodes(),
+		Edges:     NewEdges(),
+		SubGraphs: NewSubGraphs(),
+		Relations: NewRelations(),
+	}
+
+	// Nodes.
+	nodes := make(map[*Node]*Node)
+	for _, node := range g.Nodes.Nodes {
+		n := &Node{Name: node.Name, HTMLAttrs: node.HTMLAttrs.Copy(), Index: node.Index}
+		if node.Attrs != nil {
+			n.Attrs = node.Attrs.Copy()
+		}
+		nodes[node] = n
+		c.Nodes.Lookup[n.Name] = n
+		c.Nodes.Nodes = append(c.Nodes.Nodes, n)
+	}
+	cloneNodes := func(ns []*Node) []*Node {
+		if ns == nil {
+			return nil
+		}
+		clones := make([]*Node, len(ns))
+		for i, n := range ns {
+			clones[i] = nodes[n]
+		}
+		return clones
+	}
+	for _, node := range g.Nodes.Nodes {
+		n := nodes[node]
+		n.Preds = cloneNodes(node.Preds)
+		n.Succs = cloneNodes(node.Succs)
+		n.dom = domInfo{
+			idom:     nodes[node.dom.idom],
+			children: cloneNodes(node.dom.children),
+			pre:      node.dom.pre,
+			post:     node.dom.post,
+		}
+	}
+
+	// Edges.
+	edges := make(map[*Edge]*Edge)
+	for _, edge := range g.Edges.Edges {
+		e := &Edge{}
+		*e = *edge
+		if edge.Attrs != nil {
+			e.Attrs = edge.Attrs.Copy()
+		}
+		e.HTMLAttrs = edge.HTMLAttrs.Copy()
+		edges[edge] = e
+		c.Edges.Edges = append(c.Edges.Edges, e)
+	}
+	cloneEdgeMap := func(m map[string]map[string]*Edge) map[string]map[string]*Edge {
+		clone := make(map[string]map[string]*Edge, len(m))
+		for k1, es := range m {
+			clone[k1] = make(map[string]*Edge, len(es))
+			for k2, edge := range es {
+				clone[k1][k2] = edges[edge]
+			}
+		}
+		return clone
+	}
+	c.Edges.SrcToDsts = cloneEdgeMap(g.Edges.SrcToDsts)
+	c.Edges.DstToSrcs = cloneEdgeMap(g.Edges.DstToSrcs)
+
+	// Subgraphs and relations.
+	for name, sub := range g.SubGraphs.SubGraphs {
+		c.SubGraphs.SubGraphs[name] = &SubGraph{Name: sub.Name, Attrs: sub.Attrs.Copy(), HTMLAttrs: sub.HTMLAttrs.Copy(), Parent: sub.Parent}
+	}
+	for parent, children := range g.Relations.ParentToChildren {
+		for child := range children {
+			c.Relations.Add(parent, child)
+		}
+	}
+	return c
+}
+
 // In returns the number of incoming edges to name in the graph.
 func (g *Graph) In(name string) int {
 	return len(g.Edges.DstToSrcs[name])
@@ -161,7 +248,21 @@ func (this *Graph) SetName(name string) {
 //srcPort and dstPort are the port the node ports, leave as empty strings if it is not required.
 //This does not imply the adding of missing nodes.
 func (this *Graph) AddPortEdge(src, srcPort, dst, dstPort string, directed bool, attrs map[string]string) {
-	this.Edges.Add(&Edge{src, srcPort, dst, dstPort, directed, attrs})
+	this.addPortEdge(src, srcPort, dst, dstPort, directed, attrs, nil)
+}
+
+// addPortEdge adds an edge as with AddPortEdge, with the given attributes
+// whose values are HTML strings.
+func (this *Graph) addPortEdge(src, srcPort, dst, dstPort string, directed bool, attrs Attrs, html HTMLAttrs) {
+	this.Edges.Add(&Edge{
+		Src:       src,
+		SrcPort:   srcPort,
+		Dst:       dst,
+		DstPort:   dstPort,
+		Dir:       directed,
+		Attrs:     attrs,
+		HTMLAttrs: html,
+	})
 }
 
 //Adds an edge to the graph from node src to node dst.
@@ -174,9 +275,16 @@ func (this *Graph) AddEdge(src, dst string, directed bool, attrs map[string]stri
 //If not subgraph exists use the name of the main graph.
 //This does not imply the adding of a missing subgraph.
 func (this *Graph) AddNode(parentGraph string, name string, attrs map[string]string) {
+	this.addNode(parentGraph, name, attrs, nil)
+}
+
+// addNode adds a node as with AddNode, with the given attributes whose values
+// are HTML strings.
+func (this *Graph) addNode(parentGraph string, name string, attrs Attrs, html HTMLAttrs) {
 	node := &Node{
-		Name:  name,
-		Attrs: attrs,
+		Name:      name,
+		Attrs:     attrs,
+		HTMLAttrs: html,
 	}
 	this.Nodes.Add(node)
 	this.Relations.Add(parentGraph, name)
@@ -193,16 +301,42 @@ func (this *Graph) getAttrs(graphName string) Attrs {
 	return g.Attrs
 }
 
+// getHTMLAttrs returns the HTML attributes of the named graph or subgraph.
+func (this *Graph) getHTMLAttrs(graphName string) *HTMLAttrs {
+	if this.Name == graphName {
+		return &this.HTMLAttrs
+	}
+	return &this.SubGraphs.SubGraphs[graphName].HTMLAttrs
+}
+
 //Adds an attribute to a graph/subgraph.
 func (this *Graph) AddAttr(parentGraph string, field string, value string) {
+	this.addAttr(parentGraph, field, value, false)
+}
+
+// addAttr adds an attribute as with AddAttr, whose value is an HTML string if
+// html is true.
+func (this *Graph) addAttr(parentGraph string, field string, value string, html bool) {
 	this.getAttrs(parentGraph).Add(field, value)
+	this.getHTMLAttrs(parentGraph).set(field, html)
 }
 
-//Adds a subgraph to a graph/subgraph.
+//Adds a subgraph to a graph/subgraph. A subgraph which is added again, e.g.
+//a subgraph of the same name in DOT, keeps the parent of its first addition,
+//and the given attributes are added to it.
 func (this *Graph) AddSubGraph(parentGraph string, name string, attrs map[string]string) {
-	this.SubGraphs.Add(name)
+	this.addSubGraph(parentGraph, name, attrs, nil)
+}
+
+// addSubGraph adds a subgraph as with AddSubGraph, with the given attributes
+// whose values are HTML strings.
+func (this *Graph) addSubGraph(parentGraph string, name string, attrs Attrs, html HTMLAttrs) {
+	if !this.IsSubGraph(name) {
+		this.SubGraphs.Add(name)
+		this.SubGraphs.SubGraphs[name].Parent = parentGraph
+	}
 	for key, value := range attrs {
-		this.AddAttr(name, key, value)
+		this.addAttr(name, key, value, html[key])
 	}
 }
 
@@ -0,0 +1,379 @@
+package dot
+
+// This file defines the conversion of graphs to and from GraphML, as read and
+// written by e.g. yEd and Gephi.
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// graphmlNS is the XML namespace of GraphML.
+const graphmlNS = "http://graphml.graphdrawing.org/xmlns"
+
+// graphmlSubGraphsKey is the ID of the key of the subgraphs containing a node,
+// other than that of the graph holding it.
+const graphmlSubGraphsKey = "dot_subgraphs"
+
+// graphmlDoc is a GraphML document.
+type graphmlDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphmlKey `xml:"key"`
+	Graph   graphmlGraph `xml:"graph"`
+}
+
+// graphmlKey declares an attribute of graphs, nodes or edges.
+type graphmlKey struct {
+	ID      string `xml:"id,attr"`
+	For     string `xml:"for,attr,omitempty"`
+	Name    string `xml:"attr.name,attr,omitempty"`
+	Type    string `xml:"attr.type,attr,omitempty"`
+	Default string `xml:"default,omitempty"`
+}
+
+// graphmlGraph is a graph, or the nested graph of a node.
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphmlData `xml:"data"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+// graphmlNode is a node, which depicts a subgraph if it has a nested graph.
+type graphmlNode struct {
+	ID    string        `xml:"id,attr"`
+	Data  []graphmlData `xml:"data"`
+	Ports []graphmlPort `xml:"port"`
+	Graph *graphmlGraph `xml:"graph"`
+}
+
+// graphmlPort is a port of a node.
+type graphmlPort struct {
+	Name string `xml:"name,attr"`
+}
+
+// graphmlEdge is an edge.
+type graphmlEdge struct {
+	Source     string        `xml:"source,attr"`
+	Target     string        `xml:"target,attr"`
+	SourcePort string        `xml:"sourceport,attr,omitempty"`
+	TargetPort string        `xml:"targetport,attr,omitempty"`
+	Directed   string        `xml:"directed,attr,omitempty"`
+	Data       []graphmlData `xml:"data"`
+}
+
+// graphmlData is the value of an attribute.
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalGraphML returns the graph as a GraphML document.
+//
+// Attributes are declared by string-typed keys, separately for graphs, nodes
+// and edges, and their values are stored as data. Subgraphs are depicted by
+// nodes, named by the subgraph, with a nested graph holding the attributes,
+// nodes and subgraphs of the subgraph. Nodes are placed in their innermost
+// cluster, or otherwise their innermost subgraph, and other subgraphs
+// containing them are stored as data of the "dot_subgraphs" key, one for each
+// subgraph. Ports of edges are declared by the nodes, and edges with a
+// direction other than that of the graph have a directed attribute.
+// HTML strings are stored with their angle brackets. Strictness of the graph
+// is not represented.
+func (g *Graph) MarshalGraphML() ([]byte, error) {
+	w := &graphmlWriter{g: g, keys: make(map[[2]string]string), ports: make(map[string][]string)}
+
+	// Keys, in the order of graph, node and edge attributes.
+	graphAttrs := g.Attrs.Copy()
+	for _, sub := range g.SubGraphs.SubGraphs {
+		graphAttrs.Extend(sub.Attrs)
+	}
+	nodeAttrs := NewAttrs()
+	for _, node := range g.Nodes.Nodes {
+		nodeAttrs.Extend(node.Attrs)
+	}
+	edgeAttrs := NewAttrs()
+	for _, edge := range g.Edges.Edges {
+		edgeAttrs.Extend(edge.Attrs)
+	}
+	var keys []graphmlKey
+	for _, kind := range []struct {
+		name  string
+		attrs Attrs
+	}{{"graph", graphAttrs}, {"node", nodeAttrs}, {"edge", edgeAttrs}} {
+		for _, name := range kind.attrs.SortedNames() {
+			id := fmt.Sprintf("d%d", len(keys))
+			w.keys[[2]string{kind.name, name}] = id
+			keys = append(keys, graphmlKey{ID: id, For: kind.name, Name: name, Type: "string"})
+		}
+	}
+
+	for _, node := range g.Nodes.Nodes {
+		if len(w.subGraphs(node.Name)) > 0 {
+			keys = append(keys, graphmlKey{ID: graphmlSubGraphsKey, For: "node", Type: "string"})
+			break
+		}
+	}
+
+	// Ports.
+	addPort := func(name, port string) {
+		port = strings.TrimPrefix(port, ":")
+		if port == "" {
+			return
+		}
+		for _, p := range w.ports[name] {
+			if p == port {
+				return
+			}
+		}
+		w.ports[name] = append(w.ports[name], port)
+	}
+	for _, edge := range g.Edges.Edges {
+		addPort(edge.Src, edge.SrcPort)
+		addPort(edge.Dst, edge.DstPort)
+	}
+
+	doc := graphmlDoc{Xmlns: graphmlNS, Keys: keys, Graph: w.graph(g.Name)}
+	for _, edge := range g.Edges.Edges {
+		e := graphmlEdge{
+			Source:     edge.Src,
+			Target:     edge.Dst,
+			SourcePort: strings.TrimPrefix(edge.SrcPort, ":"),
+			TargetPort: strings.TrimPrefix(edge.DstPort, ":"),
+			Data:       w.data("edge", edge.Attrs),
+		}
+		if edge.Dir != g.Directed {
+			e.Directed = fmt.Sprint(edge.Dir)
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+	data, err := xml.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// graphmlWriter holds the state of writing a graph as GraphML.
+type graphmlWriter struct {
+	g *Graph
+	// Key IDs of attributes, by kind of element and attribute name.
+	keys map[[2]string]string
+	// Ports of nodes.
+	ports map[string][]string
+}
+
+// graph returns the named graph or subgraph, with its nodes and subgraphs.
+func (w *graphmlWriter) graph(name string) graphmlGraph {
+	g := w.g
+	gr := graphmlGraph{ID: name, EdgeDefault: "undirected"}
+	if g.Directed {
+		gr.EdgeDefault = "directed"
+	}
+	if name == g.Name {
+		gr.Data = w.data("graph", g.Attrs)
+	} else {
+		gr.ID = name + ":"
+		gr.Data = w.data("graph", g.SubGraphs.SubGraphs[name].Attrs)
+	}
+	for _, node := range g.Nodes.Nodes {
+		if nodeSubGraph(g, node.Name) != name {
+			continue
+		}
+		n := graphmlNode{ID: node.Name, Data: w.data("node", node.Attrs)}
+		for _, sub := range w.subGraphs(node.Name) {
+			n.Data = append(n.Data, graphmlData{Key: graphmlSubGraphsKey, Value: sub})
+		}
+		for _, port := range w.ports[node.Name] {
+			n.Ports = append(n.Ports, graphmlPort{Name: port})
+		}
+		gr.Nodes = append(gr.Nodes, n)
+	}
+	for _, sub := range childSubGraphs(g, name) {
+		nested := w.graph(sub)
+		gr.Nodes = append(gr.Nodes, graphmlNode{ID: sub, Graph: &nested})
+	}
+	return gr
+}
+
+// subGraphs returns the sorted names of the subgraphs containing the named
+// node, other than the subgraph depicting it.
+func (w *graphmlWriter) subGraphs(name string) []string {
+	var names []string
+	for _, parent := range sortedKeys(w.g.Relations.ChildToParents[name]) {
+		if w.g.IsSubGraph(parent) && parent != nodeSubGraph(w.g, name) {
+			names = append(names, parent)
+		}
+	}
+	return names
+}
+
+// data returns the data of the given attributes of the given kind of element.
+func (w *graphmlWriter) data(kind string, attrs Attrs) []graphmlData {
+	var data []graphmlData
+	for _, name := range attrs.SortedNames() {
+		data = append(data, graphmlData{Key: w.keys[[2]string{kind, name}], Value: attrs[name]})
+	}
+	return data
+}
+
+// ReadGraphML reads a graph from a GraphML document.
+//
+// Keys declare the names of attributes, and keys without names, e.g. the
+// graphics of yEd, are ignored. Nodes with nested graphs are read as
+// subgraphs, named by the node, holding the attributes of the nested graph,
+// and nodes are also added to the subgraphs given by data of the
+// "dot_subgraphs" key.
+// Ports of edges are read as the ports of their nodes, and values enclosing
+// well-formed markup in angle brackets, e.g. "<<b>bold</b>>", are read as HTML
+// strings.
+func ReadGraphML(data []byte) (*Graph, error) {
+	var doc graphmlDoc
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	r := &graphmlReader{g: NewGraph(), keys: make(map[string]graphmlKey)}
+	for _, key := range doc.Keys {
+		r.keys[key.ID] = key
+	}
+	g := r.g
+	g.SetName(doc.Graph.ID)
+	g.SetDir(doc.Graph.EdgeDefault != "undirected")
+	attrs, html, err := r.attrs("graph", doc.Graph.Data)
+	if err != nil {
+		return nil, fmt.Errorf("graph %s: %v", Quote(g.Name), err)
+	}
+	g.Attrs, g.HTMLAttrs = attrs, html
+	if err := r.graph(g.Name, &doc.Graph); err != nil {
+		return nil, err
+	}
+	for _, m := range r.members {
+		if !g.IsSubGraph(m[0]) {
+			return nil, fmt.Errorf("node %s: no subgraph %s", Quote(m[1]), Quote(m[0]))
+		}
+		g.Relations.Add(m[0], m[1])
+	}
+
+	// Edges.
+	for _, e := range r.edges {
+		for _, end := range []string{e.Source, e.Target} {
+			if !g.IsNode(end) && !g.IsSubGraph(end) {
+				return nil, fmt.Errorf("edge %s -> %s: no node %s", Quote(e.Source), Quote(e.Target), Quote(end))
+			}
+		}
+		attrs, html, err := r.attrs("edge", e.Data)
+		if err != nil {
+			return nil, fmt.Errorf("edge %s -> %s: %v", Quote(e.Source), Quote(e.Target), err)
+		}
+		dir := e.dir
+		switch e.Directed {
+		case "true":
+			dir = true
+		case "false":
+			dir = false
+		}
+		edge := &Edge{Src: e.Source, Dst: e.Target, Dir: dir, Attrs: attrs, HTMLAttrs: html}
+		if e.SourcePort != "" {
+			edge.SrcPort = ":" + e.SourcePort
+		}
+		if e.TargetPort != "" {
+			edge.DstPort = ":" + e.TargetPort
+		}
+		g.Edges.Add(edge)
+	}
+	linkNodes(g)
+	return g, nil
+}
+
+// graphmlReader holds the state of reading a graph from GraphML.
+type graphmlReader struct {
+	g *Graph
+	// Keys, by ID.
+	keys map[string]graphmlKey
+	// Edges of the graph and nested graphs.
+	edges []graphmlReaderEdge
+	// Subgraphs containing nodes, given by data of the "dot_subgraphs" key,
+	// as pairs of subgraph and node names.
+	members [][2]string
+}
+
+// graphmlReaderEdge is an edge, with the edge direction of its graph.
+type graphmlReaderEdge struct {
+	graphmlEdge
+	dir bool
+}
+
+// graph adds the nodes and subgraphs of the given graph, which is the named
+// graph or subgraph, and records its edges.
+func (r *graphmlReader) graph(name string, gr *graphmlGraph) error {
+	dir := r.g.Directed
+	if gr.EdgeDefault != "" {
+		dir = gr.EdgeDefault == "directed"
+	}
+	for _, e := range gr.Edges {
+		r.edges = append(r.edges, graphmlReaderEdge{graphmlEdge: e, dir: dir})
+	}
+	for i := range gr.Nodes {
+		n := &gr.Nodes[i]
+		if n.Graph != nil {
+			if r.g.IsNode(n.ID) || r.g.IsSubGraph(n.ID) {
+				return fmt.Errorf("subgraph %s: name already used", Quote(n.ID))
+			}
+			attrs, html, err := r.attrs("graph", n.Graph.Data)
+			if err != nil {
+				return fmt.Errorf("subgraph %s: %v", Quote(n.ID), err)
+			}
+			r.g.addSubGraph(name, n.ID, attrs, html)
+			if err := r.graph(n.ID, n.Graph); err != nil {
+				return err
+			}
+			continue
+		}
+		if r.g.IsSubGraph(n.ID) {
+			return fmt.Errorf("node %s: name already used by a subgraph", Quote(n.ID))
+		}
+		attrs, html, err := r.attrs("node", n.Data)
+		if err != nil {
+			return fmt.Errorf("node %s: %v", Quote(n.ID), err)
+		}
+		r.g.addNode(name, n.ID, attrs, html)
+		for _, d := range n.Data {
+			if d.Key == graphmlSubGraphsKey {
+				r.members = append(r.members, [2]string{d.Value, n.ID})
+			}
+		}
+	}
+	return nil
+}
+
+// attrs returns the attributes of the given data of the given kind of element,
+// including the defaults of its keys, and the names of those which are HTML
+// strings.
+func (r *graphmlReader) attrs(kind string, data []graphmlData) (Attrs, HTMLAttrs, error) {
+	attrs := NewAttrs()
+	var html HTMLAttrs
+	for _, key := range r.keys {
+		if key.Name != "" && key.Default != "" && (key.For == kind || key.For == "all") {
+			attrs[key.Name] = key.Default
+			html.set(key.Name, isHtml(key.Default))
+		}
+	}
+	for _, d := range data {
+		if d.Key == graphmlSubGraphsKey {
+			continue
+		}
+		key, ok := r.keys[d.Key]
+		if !ok {
+			return nil, nil, fmt.Errorf("undeclared key %q", d.Key)
+		}
+		if key.Name == "" {
+			continue
+		}
+		attrs[key.Name] = d.Value
+		html.set(key.Name, isHtml(d.Value))
+	}
+	return attrs, html, nil
+}
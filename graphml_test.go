@@ -0,0 +1,112 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphML(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_outer {
+			label="outer";
+			a [label=<<b>A</b>>];
+			subgraph cluster_inner { label="inner"; b [shape=record, label="<f0> x|<f1> y"]; }
+		}
+		c;
+		a -> b:f0:n [color=red];
+		c -> b:f1;
+	}`))
+	check(t, err)
+	g.AddEdge("c", "cluster_inner", true, NewAttrs())
+	data, err := g.MarshalGraphML()
+	check(t, err)
+	s := string(data)
+	for _, want := range []string{
+		`<key id="d0" for="graph" attr.name="label" attr.type="string"></key>`,
+		`<graph id="G" edgedefault="directed">`,
+		`<node id="cluster_outer">`,
+		`<graph id="cluster_inner:" edgedefault="directed">`,
+		`<port name="f0:n"></port>`,
+		`<edge source="a" target="b" targetport="f0:n">`,
+		`<edge source="c" target="cluster_inner">`,
+		`&lt;&lt;b&gt;A&lt;/b&gt;&gt;`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("missing %s in\n%s", want, s)
+		}
+	}
+
+	h, err := ReadGraphML(data)
+	check(t, err)
+	assert(t, "round trip", Equal(g, h), true)
+	assert(t, "nested", h.SubGraphs.SubGraphs["cluster_inner"].Parent, "cluster_outer")
+	assert(t, "member", h.Relations.ChildToParents["b"]["cluster_inner"], true)
+	assert(t, "html", h.Nodes.Lookup["a"].HTMLAttrs["label"], true)
+	assert(t, "not html", h.Nodes.Lookup["b"].HTMLAttrs["label"], false)
+}
+
+func TestReadGraphML(t *testing.T) {
+	// Abridged output of yEd.
+	const src = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:y="http://www.yworks.com/xml/graphml">
+  <key for="node" id="d0" yfiles.type="nodegraphics"/>
+  <key attr.name="weight" attr.type="double" for="edge" id="d1"><default>1.0</default></key>
+  <key attr.name="description" attr.type="string" for="all" id="d2"/>
+  <graph edgedefault="undirected" id="G">
+    <node id="n0">
+      <data key="d0"><y:ShapeNode><y:NodeLabel>A</y:NodeLabel></y:ShapeNode></data>
+      <data key="d2">first</data>
+    </node>
+    <node id="n1"/>
+    <edge source="n0" target="n1" directed="true"/>
+    <edge source="n1" target="n0"><data key="d1">2.5</data></edge>
+  </graph>
+</graphml>`
+	g, err := ReadGraphML([]byte(src))
+	check(t, err)
+	assert(t, "name", g.Name, "G")
+	assert(t, "directed", g.Directed, false)
+	assert(t, "nodes", len(g.Nodes.Nodes), 2)
+	assert(t, "attr", g.Nodes.Lookup["n0"].Attrs["description"], "first")
+	assert(t, "graphics ignored", len(g.Nodes.Lookup["n0"].Attrs), 1)
+	assert(t, "edges", len(g.Edges.Edges), 2)
+	assert(t, "edge direction", g.Edges.Edges[0].Dir, true)
+	assert(t, "default direction", g.Edges.Edges[1].Dir, false)
+	assert(t, "default", g.Edges.Edges[0].Attrs["weight"], "1.0")
+	assert(t, "data", g.Edges.Edges[1].Attrs["weight"], "2.5")
+
+	invalid := []string{
+		`<graphml><graph><node id="a"/><edge source="a" target="b"/></graph></graphml>`,
+		`<graphml><graph><node id="a"><data key="k">x</data></node></graph></graphml>`,
+		`<graphml><graph><node id="a"/><node id="a"><graph/></node></graph></graphml>`,
+		`<graphml><graph>`,
+	}
+	for _, src := range invalid {
+		if _, err := ReadGraphML([]byte(src)); err == nil {
+			t.Errorf("%s: expected error", src)
+		}
+	}
+}
+
+func TestGraphMLSubGraphs(t *testing.T) {
+	// Nodes are placed in their innermost cluster, and other subgraphs
+	// containing them are recorded.
+	g, err := Read([]byte(`digraph G {
+		subgraph cluster_a { subgraph cluster_b { y } }
+		{ rank=same; y; z }
+	}`))
+	check(t, err)
+	data, err := g.MarshalGraphML()
+	check(t, err)
+	h, err := ReadGraphML(data)
+	check(t, err)
+	assert(t, "round trip", Equal(g, h), true)
+	assert(t, "cluster", h.Relations.ChildToParents["y"]["cluster_b"], true)
+	assert(t, "subgraphs", len(h.Relations.ChildToParents["y"]), 2)
+
+	invalid := `<graphml><key id="dot_subgraphs" for="node"/><graph><node id="a"><data key="dot_subgraphs">s</data></node></graph></graphml>`
+	if _, err := ReadGraphML([]byte(invalid)); err == nil {
+		t.Errorf("expected error for unknown subgraph")
+	}
+}
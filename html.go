@@ -0,0 +1,626 @@
+package dot
+
+// This file defines the parsing, validation and writing of HTML-like labels,
+// as documented at https://graphviz.org/doc/info/shapes.html#html
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HTMLTag is the tag name of an element of an HTML-like label, in upper case.
+type HTMLTag string
+
+// Tags of HTML-like labels.
+const (
+	HTMLTable HTMLTag = "TABLE"
+	HTMLTR    HTMLTag = "TR"
+	HTMLTD    HTMLTag = "TD"
+	HTMLFont  HTMLTag = "FONT"
+	HTMLImg   HTMLTag = "IMG"
+	HTMLBR    HTMLTag = "BR"
+	HTMLHR    HTMLTag = "HR"
+	HTMLVR    HTMLTag = "VR"
+	HTMLB     HTMLTag = "B"
+	HTMLI     HTMLTag = "I"
+	HTMLU     HTMLTag = "U"
+	HTMLO     HTMLTag = "O"
+	HTMLS     HTMLTag = "S"
+	HTMLSub   HTMLTag = "SUB"
+	HTMLSup   HTMLTag = "SUP"
+)
+
+// htmlAttrs maps from tags to their valid attribute names.
+var htmlAttrs = map[HTMLTag][]string{
+	HTMLTable: {"ALIGN", "BALIGN", "BGCOLOR", "BORDER", "CELLBORDER", "CELLPADDING", "CELLSPACING", "COLOR", "COLUMNS", "FIXEDSIZE", "GRADIENTANGLE", "HEIGHT", "HREF", "ID", "PORT", "ROWS", "SIDES", "STYLE", "TARGET", "TITLE", "TOOLTIP", "VALIGN", "WIDTH"},
+	HTMLTR:    {},
+	HTMLTD:    {"ALIGN", "BALIGN", "BGCOLOR", "BORDER", "CELLPADDING", "CELLSPACING", "COLOR", "COLSPAN", "FIXEDSIZE", "GRADIENTANGLE", "HEIGHT", "HREF", "ID", "PORT", "ROWSPAN", "SIDES", "STYLE", "TARGET", "TITLE", "TOOLTIP", "VALIGN", "WIDTH"},
+	HTMLFont:  {"COLOR", "FACE", "POINT-SIZE"},
+	HTMLImg:   {"SCALE", "SRC"},
+	HTMLBR:    {"ALIGN"},
+	HTMLHR:    {},
+	HTMLVR:    {},
+	HTMLB:     {},
+	HTMLI:     {},
+	HTMLU:     {},
+	HTMLO:     {},
+	HTMLS:     {},
+	HTMLSub:   {},
+	HTMLSup:   {},
+}
+
+// isEmptyTag reports whether elements of the tag have no content.
+func isEmptyTag(tag HTMLTag) bool {
+	return tag == HTMLImg || tag == HTMLBR || tag == HTMLHR || tag == HTMLVR
+}
+
+// isTextTag reports whether the tag is a text style, which may enclose text or
+// a table.
+func isTextTag(tag HTMLTag) bool {
+	switch tag {
+	case HTMLFont, HTMLB, HTMLI, HTMLU, HTMLO, HTMLS, HTMLSub, HTMLSup:
+		return true
+	}
+	return false
+}
+
+// HTMLNode is a node of an HTML-like label; either an *HTMLElement or
+// HTMLText.
+type HTMLNode interface {
+	// String returns the HTML of the node.
+	String() string
+	isHTMLNode()
+}
+
+// HTMLText is character data of an HTML-like label. Entities, e.g. "&amp;",
+// are retained as written.
+type HTMLText string
+
+func (text HTMLText) String() string {
+	return string(text)
+}
+
+// isSpace reports whether the text consists only of whitespace.
+func (text HTMLText) isSpace() bool {
+	return strings.TrimSpace(string(text)) == ""
+}
+
+// HTMLAttr is an attribute of an element of an HTML-like label.
+type HTMLAttr struct {
+	// Attribute name in upper case.
+	Name  string
+	Value string
+}
+
+// HTMLElement is an element of an HTML-like label.
+type HTMLElement struct {
+	Tag HTMLTag
+	// Attributes in order of declaration.
+	Attrs    []HTMLAttr
+	Children []HTMLNode
+}
+
+func (*HTMLElement) isHTMLNode() {}
+func (HTMLText) isHTMLNode()     {}
+
+// Attr returns the value of the named attribute of the element, or the empty
+// string if absent. Attribute names are case-insensitive.
+func (elem *HTMLElement) Attr(name string) string {
+	name = strings.ToUpper(name)
+	for _, attr := range elem.Attrs {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// SetAttr sets the named attribute of the element.
+func (elem *HTMLElement) SetAttr(name, value string) {
+	name = strings.ToUpper(name)
+	for i, attr := range elem.Attrs {
+		if attr.Name == name {
+			elem.Attrs[i].Value = value
+			return
+		}
+	}
+	elem.Attrs = append(elem.Attrs, HTMLAttr{Name: name, Value: value})
+}
+
+func (elem *HTMLElement) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString("<" + string(elem.Tag))
+	for _, attr := range elem.Attrs {
+		fmt.Fprintf(buf, ` %s="%s"`, attr.Name, strings.Replace(attr.Value, `"`, "&quot;", -1))
+	}
+	if isEmptyTag(elem.Tag) {
+		buf.WriteString("/>")
+		return buf.String()
+	}
+	buf.WriteString(">")
+	for _, child := range elem.Children {
+		buf.WriteString(child.String())
+	}
+	buf.WriteString("</" + string(elem.Tag) + ">")
+	return buf.String()
+}
+
+// HTMLLabel is a parsed HTML-like label.
+type HTMLLabel struct {
+	Nodes []HTMLNode
+}
+
+// String returns the label as a DOT HTML string, enclosed in angle brackets.
+func (label *HTMLLabel) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString("<")
+	for _, node := range label.Nodes {
+		buf.WriteString(node.String())
+	}
+	buf.WriteString(">")
+	return buf.String()
+}
+
+// Ports returns the PORT attributes declared by the tables and cells of the
+// label, in order.
+func (label *HTMLLabel) Ports() []string {
+	var ports []string
+	label.Walk(func(elem *HTMLElement) {
+		if port := elem.Attr("PORT"); port != "" {
+			ports = append(ports, port)
+		}
+	})
+	return ports
+}
+
+// Walk calls visit for each element of the label, in preorder.
+func (label *HTMLLabel) Walk(visit func(elem *HTMLElement)) {
+	var walk func(nodes []HTMLNode)
+	walk = func(nodes []HTMLNode) {
+		for _, node := range nodes {
+			if elem, ok := node.(*HTMLElement); ok {
+				visit(elem)
+				walk(elem.Children)
+			}
+		}
+	}
+	walk(label.Nodes)
+}
+
+// ParseHTML parses and validates the given HTML-like label. The label may be
+// enclosed in angle brackets, as in DOT HTML strings, which are stripped if the
+// enclosed label is well-formed.
+func ParseHTML(s string) (*HTMLLabel, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '<' && s[len(s)-1] == '>' {
+		if _, err := (&htmlParser{s: s[1 : len(s)-1]}).parse(); err == nil {
+			s = s[1 : len(s)-1]
+		}
+	}
+	nodes, err := (&htmlParser{s: s}).parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTML label; %v", err)
+	}
+	label := &HTMLLabel{Nodes: nodes}
+	if err := label.Validate(); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// htmlParser holds the state of parsing an HTML-like label.
+type htmlParser struct {
+	s   string
+	pos int
+}
+
+// parse parses the nodes of the label.
+func (p *htmlParser) parse() ([]HTMLNode, error) {
+	nodes, end, err := p.nodes()
+	if err != nil {
+		return nil, err
+	}
+	if end != "" {
+		return nil, fmt.Errorf("unexpected end tag </%s>", end)
+	}
+	return nodes, nil
+}
+
+// nodes parses nodes until an end tag or the end of input, and returns the
+// name of the end tag, if any.
+func (p *htmlParser) nodes() ([]HTMLNode, HTMLTag, error) {
+	var nodes []HTMLNode
+	for p.pos < len(p.s) {
+		if p.s[p.pos] != '<' {
+			end := strings.IndexByte(p.s[p.pos:], '<')
+			if end == -1 {
+				end = len(p.s) - p.pos
+			}
+			text := p.s[p.pos : p.pos+end]
+			if strings.IndexByte(text, '>') != -1 {
+				return nil, "", fmt.Errorf("unexpected '>' in text %q", text)
+			}
+			nodes = append(nodes, HTMLText(text))
+			p.pos += end
+			continue
+		}
+		if strings.HasPrefix(p.s[p.pos:], "<!--") {
+			end := strings.Index(p.s[p.pos:], "-->")
+			if end == -1 {
+				return nil, "", fmt.Errorf("unterminated comment")
+			}
+			p.pos += end + len("-->")
+			continue
+		}
+		if strings.HasPrefix(p.s[p.pos:], "</") {
+			end := strings.IndexByte(p.s[p.pos:], '>')
+			if end == -1 {
+				return nil, "", fmt.Errorf("unterminated end tag")
+			}
+			tag := HTMLTag(strings.ToUpper(strings.TrimSpace(p.s[p.pos+2 : p.pos+end])))
+			p.pos += end + 1
+			return nodes, tag, nil
+		}
+		elem, err := p.element()
+		if err != nil {
+			return nil, "", err
+		}
+		nodes = append(nodes, elem)
+	}
+	return nodes, "", nil
+}
+
+// element parses an element, starting at its start tag.
+func (p *htmlParser) element() (*HTMLElement, error) {
+	p.pos++ // '<'
+	name := p.name()
+	if name == "" {
+		return nil, fmt.Errorf("missing tag name at offset %d", p.pos)
+	}
+	elem := &HTMLElement{Tag: HTMLTag(strings.ToUpper(name))}
+	if _, ok := htmlAttrs[elem.Tag]; !ok {
+		return nil, fmt.Errorf("unknown tag <%s>", name)
+	}
+	for {
+		p.skipSpace()
+		if p.pos == len(p.s) {
+			return nil, fmt.Errorf("unterminated tag <%s>", name)
+		}
+		if strings.HasPrefix(p.s[p.pos:], "/>") {
+			p.pos += 2
+			return elem, nil
+		}
+		if p.s[p.pos] == '>' {
+			p.pos++
+			break
+		}
+		attr, err := p.attr()
+		if err != nil {
+			return nil, fmt.Errorf("tag <%s>: %v", name, err)
+		}
+		elem.Attrs = append(elem.Attrs, attr)
+	}
+	children, end, err := p.nodes()
+	if err != nil {
+		return nil, err
+	}
+	if end != elem.Tag {
+		if end == "" {
+			return nil, fmt.Errorf("missing end tag </%s>", elem.Tag)
+		}
+		return nil, fmt.Errorf("end tag </%s> does not match <%s>", end, elem.Tag)
+	}
+	elem.Children = children
+	return elem, nil
+}
+
+// attr parses an attribute of the form NAME="value" or NAME='value'.
+func (p *htmlParser) attr() (HTMLAttr, error) {
+	name := p.name()
+	if name == "" {
+		return HTMLAttr{}, fmt.Errorf("invalid attribute at offset %d", p.pos)
+	}
+	p.skipSpace()
+	if p.pos == len(p.s) || p.s[p.pos] != '=' {
+		return HTMLAttr{}, fmt.Errorf("missing value of attribute %s", name)
+	}
+	p.pos++
+	p.skipSpace()
+	if p.pos == len(p.s) || (p.s[p.pos] != '"' && p.s[p.pos] != '\'') {
+		return HTMLAttr{}, fmt.Errorf("unquoted value of attribute %s", name)
+	}
+	quote := p.s[p.pos]
+	end := strings.IndexByte(p.s[p.pos+1:], quote)
+	if end == -1 {
+		return HTMLAttr{}, fmt.Errorf("unterminated value of attribute %s", name)
+	}
+	value := p.s[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	value = strings.Replace(value, "&quot;", `"`, -1)
+	return HTMLAttr{Name: strings.ToUpper(name), Value: value}, nil
+}
+
+// name parses a tag or attribute name.
+func (p *htmlParser) name() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// skipSpace skips whitespace.
+func (p *htmlParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) != -1 {
+		p.pos++
+	}
+}
+
+// Validate reports an error if the label does not conform to the grammar of
+// HTML-like labels, or if an attribute is invalid.
+func (label *HTMLLabel) Validate() error {
+	return validateLabel(label.Nodes)
+}
+
+// validateLabel validates the contents of a label or a table cell; i.e. either
+// text, or a table optionally enclosed in FONT, B, I, U or O elements.
+func validateLabel(nodes []HTMLNode) error {
+	if elem := singleElement(nodes); elem != nil && containsTable(elem) {
+		return validateFontTable(elem)
+	}
+	return validateText(nodes)
+}
+
+// singleElement returns the only element of the given nodes if all other nodes
+// are whitespace, and otherwise nil.
+func singleElement(nodes []HTMLNode) *HTMLElement {
+	var elem *HTMLElement
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case HTMLText:
+			if !node.isSpace() {
+				return nil
+			}
+		case *HTMLElement:
+			if elem != nil {
+				return nil
+			}
+			elem = node
+		}
+	}
+	return elem
+}
+
+// containsTable reports whether the element is a table, or a text style
+// enclosing one.
+func containsTable(elem *HTMLElement) bool {
+	if elem.Tag == HTMLTable {
+		return true
+	}
+	if !isTextTag(elem.Tag) {
+		return false
+	}
+	inner := singleElement(elem.Children)
+	return inner != nil && containsTable(inner)
+}
+
+// validateFontTable validates a table optionally enclosed in text styles.
+func validateFontTable(elem *HTMLElement) error {
+	if err := validateAttrs(elem); err != nil {
+		return err
+	}
+	switch elem.Tag {
+	case HTMLTable:
+		return validateTable(elem)
+	case HTMLFont, HTMLB, HTMLI, HTMLU, HTMLO:
+		return validateFontTable(singleElement(elem.Children))
+	}
+	return fmt.Errorf("<%s> may not enclose a table", elem.Tag)
+}
+
+// validateText validates text, which may contain line breaks and text styles.
+func validateText(nodes []HTMLNode) error {
+	for _, node := range nodes {
+		elem, ok := node.(*HTMLElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case elem.Tag == HTMLBR:
+			if err := validateAttrs(elem); err != nil {
+				return err
+			}
+		case isTextTag(elem.Tag):
+			if err := validateAttrs(elem); err != nil {
+				return err
+			}
+			if err := validateText(elem.Children); err != nil {
+				return err
+			}
+		case elem.Tag == HTMLTable:
+			return fmt.Errorf("<TABLE> may not be mixed with text")
+		default:
+			return fmt.Errorf("<%s> not allowed in text", elem.Tag)
+		}
+	}
+	return nil
+}
+
+// validateTable validates a table; i.e. rows optionally separated by
+// horizontal rules.
+func validateTable(table *HTMLElement) error {
+	return validateList(table, HTMLTR, HTMLHR, validateRow)
+}
+
+// validateRow validates a table row; i.e. cells optionally separated by
+// vertical rules.
+func validateRow(row *HTMLElement) error {
+	return validateList(row, HTMLTD, HTMLVR, validateCell)
+}
+
+// validateList validates the children of the given element, which must be at
+// least one item, optionally separated by rules.
+func validateList(elem *HTMLElement, item, rule HTMLTag, validateItem func(elem *HTMLElement) error) error {
+	if err := validateAttrs(elem); err != nil {
+		return err
+	}
+	items := 0
+	// Rules may only separate items.
+	afterRule := true
+	for _, node := range elem.Children {
+		switch node := node.(type) {
+		case HTMLText:
+			if !node.isSpace() {
+				return fmt.Errorf("text %q not allowed in <%s>", strings.TrimSpace(string(node)), elem.Tag)
+			}
+		case *HTMLElement:
+			switch node.Tag {
+			case item:
+				if err := validateItem(node); err != nil {
+					return err
+				}
+				items++
+				afterRule = false
+			case rule:
+				if afterRule {
+					return fmt.Errorf("<%s/> must separate each <%s> of <%s>", rule, item, elem.Tag)
+				}
+				if err := validateAttrs(node); err != nil {
+					return err
+				}
+				afterRule = true
+			default:
+				return fmt.Errorf("<%s> not allowed in <%s>", node.Tag, elem.Tag)
+			}
+		}
+	}
+	if items == 0 {
+		return fmt.Errorf("<%s> without <%s>", elem.Tag, item)
+	}
+	if afterRule {
+		return fmt.Errorf("<%s/> must separate each <%s> of <%s>", rule, item, elem.Tag)
+	}
+	return nil
+}
+
+// validateCell validates a table cell; i.e. a label or an image.
+func validateCell(cell *HTMLElement) error {
+	if err := validateAttrs(cell); err != nil {
+		return err
+	}
+	if img := singleElement(cell.Children); img != nil && img.Tag == HTMLImg {
+		return validateAttrs(img)
+	}
+	return validateLabel(cell.Children)
+}
+
+// Enumerated values of attributes of HTML-like labels.
+var (
+	htmlAligns      = []string{"CENTER", "LEFT", "RIGHT"}
+	htmlCellAligns  = []string{"CENTER", "LEFT", "RIGHT", "TEXT"}
+	htmlVAligns     = []string{"MIDDLE", "BOTTOM", "TOP"}
+	htmlBools       = []string{"FALSE", "TRUE"}
+	htmlScales      = []string{"FALSE", "TRUE", "WIDTH", "HEIGHT", "BOTH"}
+	htmlStyles      = []string{"ROUNDED", "RADIAL", "SOLID", "DOTTED", "DASHED", "INVISIBLE", "INVIS"}
+	htmlIntAttrs    = []string{"BORDER", "CELLBORDER", "CELLPADDING", "CELLSPACING", "COLSPAN", "ROWSPAN", "WIDTH", "HEIGHT", "GRADIENTANGLE"}
+	htmlStarAttrs   = []string{"COLUMNS", "ROWS"}
+	htmlColorAttrs  = []string{"COLOR"}
+	htmlColorsAttrs = []string{"BGCOLOR"}
+)
+
+// validateAttrs validates the attributes of the element.
+func validateAttrs(elem *HTMLElement) error {
+	for _, attr := range elem.Attrs {
+		if !contains(htmlAttrs[elem.Tag], attr.Name) {
+			return fmt.Errorf("unknown attribute %s of <%s>%s", attr.Name, elem.Tag, suggest(attr.Name, htmlAttrs[elem.Tag]))
+		}
+		if err := validateHTMLAttr(elem.Tag, attr); err != nil {
+			return fmt.Errorf("invalid value %q of attribute %s of <%s>; %v", attr.Value, attr.Name, elem.Tag, err)
+		}
+	}
+	if elem.Tag == HTMLImg && elem.Attr("SRC") == "" {
+		return fmt.Errorf("<IMG> without SRC attribute")
+	}
+	return nil
+}
+
+// validateHTMLAttr validates the value of the given attribute of an element
+// with the given tag.
+func validateHTMLAttr(tag HTMLTag, attr HTMLAttr) error {
+	value := attr.Value
+	upper := strings.ToUpper(value)
+	enum := func(valid []string) error {
+		if !contains(valid, upper) {
+			return fmt.Errorf("expected one of %s", strings.Join(valid, ", "))
+		}
+		return nil
+	}
+	switch name := attr.Name; {
+	case name == "ALIGN" && tag == HTMLTD:
+		return enum(htmlCellAligns)
+	case name == "ALIGN" || name == "BALIGN":
+		return enum(htmlAligns)
+	case name == "VALIGN":
+		return enum(htmlVAligns)
+	case name == "FIXEDSIZE":
+		return enum(htmlBools)
+	case name == "SCALE":
+		return enum(htmlScales)
+	case name == "STYLE":
+		for _, style := range strings.Split(upper, ",") {
+			if !contains(htmlStyles, strings.TrimSpace(style)) {
+				return fmt.Errorf("expected styles of %s", strings.Join(htmlStyles, ", "))
+			}
+		}
+	case name == "SIDES":
+		if value == "" || strings.Trim(upper, "LTRB") != "" {
+			return fmt.Errorf("expected combination of L, T, R and B")
+		}
+	case name == "POINT-SIZE":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("expected number")
+		}
+	case contains(htmlIntAttrs, name):
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("expected integer")
+		}
+	case contains(htmlStarAttrs, name):
+		if value != "*" {
+			return fmt.Errorf(`expected "*"`)
+		}
+	case contains(htmlColorAttrs, name):
+		return TypeColor.Validate(value)
+	case contains(htmlColorsAttrs, name):
+		return TypeColorList.Validate(value)
+	}
+	return nil
+}
+
+// HTMLLabel returns the parsed HTML-like label of the node, or nil if the
+// label of the node is not an HTML string, as recorded by its HTMLAttrs.
+func (n *Node) HTMLLabel() (*HTMLLabel, error) {
+	label, ok := n.Attrs["label"]
+	if !ok || !n.HTMLAttrs["label"] {
+		return nil, nil
+	}
+	return ParseHTML(label)
+}
+
+// SetHTMLLabel sets the label of the node to the given HTML-like label, and
+// records the label as an HTML string.
+func (n *Node) SetHTMLLabel(label *HTMLLabel) error {
+	if err := label.Validate(); err != nil {
+		return err
+	}
+	if n.Attrs == nil {
+		n.Attrs = NewAttrs()
+	}
+	n.Attrs["label"] = label.String()
+	n.HTMLAttrs.set("label", true)
+	return nil
+}
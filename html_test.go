@@ -0,0 +1,94 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	label, err := ParseHTML(`<<font point-size="10"><table border='0' cellborder="1">
+		<tr><td port="in">in</td><VR/><td rowspan="2" PORT="body"><b>body</b><br align="left"/>&amp; more</td></tr>
+		<hr/>
+		<tr><td port="out"><img src="x.png"/></td></tr>
+	</table></font>>`)
+	check(t, err)
+	font := label.Nodes[0].(*HTMLElement)
+	assert(t, "font tag", font.Tag, HTMLFont)
+	assert(t, "point size", font.Attr("point-size"), "10")
+	assert(t, "ports", strings.Join(label.Ports(), ","), "in,body,out")
+	assert(t, "string", label.String(), `<<FONT POINT-SIZE="10"><TABLE BORDER="0" CELLBORDER="1">
+		<TR><TD PORT="in">in</TD><VR/><TD ROWSPAN="2" PORT="body"><B>body</B><BR ALIGN="left"/>&amp; more</TD></TR>
+		<HR/>
+		<TR><TD PORT="out"><IMG SRC="x.png"/></TD></TR>
+	</TABLE></FONT>>`)
+	again, err := ParseHTML(label.String())
+	check(t, err)
+	assert(t, "round trip", again.String(), label.String())
+
+	label, err = ParseHTML(`<b>bold</b> and <i>italic</i>`)
+	check(t, err)
+	assert(t, "text", label.String(), `<<B>bold</B> and <I>italic</I>>`)
+	assert(t, "no ports", len(label.Ports()), 0)
+}
+
+func TestValidateHTML(t *testing.T) {
+	invalid := map[string]string{
+		`<<b>x</i>>`:                  "mismatched end tag",
+		`<<b>x>`:                      "missing end tag",
+		`<<blink>x</blink>>`:          "unknown tag",
+		`<<table><td>x</td></table>>`: "cell outside row",
+		`<<table><tr><td>x</td></tr></table> text>`:                "table mixed with text",
+		`<<table></table>>`:                                        "table without rows",
+		`<<table><hr/><tr><td>x</td></tr></table>>`:                "leading rule",
+		`<<table><tr><td>x</td><vr/><vr/><td>y</td></tr></table>>`: "consecutive rules",
+		`<<table><tr><td borderr="1">x</td></tr></table>>`:         "unknown attribute",
+		`<<table><tr><td align="middle">x</td></tr></table>>`:      "invalid enum",
+		`<<table><tr><td colspan="two">x</td></tr></table>>`:       "invalid integer",
+		`<<font color="#12">x</font>>`:                             "invalid colour",
+		`<<table><tr><td><img/></td></tr></table>>`:                "image without source",
+		`<x<img src="a.png"/>>`:                                    "image in text",
+		`<<sub><table><tr><td>x</td></tr></table></sub>>`:          "table in subscript",
+		`<<font color=red>x</font>>`:                               "unquoted value",
+	}
+	for s, desc := range invalid {
+		if _, err := ParseHTML(s); err == nil {
+			t.Errorf("%s: expected error for %q", desc, s)
+		}
+	}
+}
+
+func TestHTMLPorts(t *testing.T) {
+	g, err := Read([]byte(`digraph {
+		a [shape=plain, label=<<table><tr><td port="p1">1</td><td port="p2">2</td></tr></table>>];
+		b [label=<plain <b>text</b>>];
+		a:p1 -> b;
+		a:p3:s -> b:p1;
+		b:s -> a:p2:n;
+	}`))
+	check(t, err)
+	var got []string
+	for _, issue := range CheckPorts(g) {
+		got = append(got, issue.String())
+	}
+	assert(t, "issues", strings.Join(got, "\n"), strings.Join([]string{
+		`edge a:p3:s->b:p1: port "p3" not declared by HTML label of node a`,
+		`edge a:p3:s->b:p1: port "p1" not declared by HTML label of node b`,
+	}, "\n"))
+
+	a := g.Nodes.Lookup["a"]
+	label, err := a.HTMLLabel()
+	check(t, err)
+	var cells []*HTMLElement
+	label.Walk(func(elem *HTMLElement) {
+		if elem.Tag == HTMLTD {
+			cells = append(cells, elem)
+		}
+	})
+	cells[1].SetAttr("port", "p3")
+	check(t, a.SetHTMLLabel(label))
+	assert(t, "ports", strings.Join(label.Ports(), ","), "p1,p3")
+	// Port p3 is now declared, whereas p2 is no longer.
+	assert(t, "remaining issues", len(CheckPorts(g)), 2)
+	assert(t, "is HTML", a.HTMLAttrs["label"], true)
+	assert(t, "not HTML", isHtml("<a<b>"), false)
+}
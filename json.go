@@ -0,0 +1,363 @@
+package dot
+
+// This file defines the conversion of graphs to and from the JSON format of
+// Graphviz, as output by `dot -Tjson0` and `dot -Tjson`.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonKeys are the keys of JSON objects which are not attributes.
+var jsonKeys = map[string]bool{
+	"name":          true,
+	"directed":      true,
+	"strict":        true,
+	"_subgraph_cnt": true,
+	"_gvid":         true,
+	"objects":       true,
+	"subgraphs":     true,
+	"nodes":         true,
+	"edges":         true,
+	"tail":          true,
+	"head":          true,
+}
+
+// MarshalJSON returns the graph in the JSON format of Graphviz without layout
+// information, as output by `dot -Tjson0`.
+//
+// The "objects" array holds the subgraphs, in depth-first order, followed by
+// the nodes, and the "_gvid" of each object is its index. The "subgraphs",
+// "nodes" and "edges" arrays of the graph and subgraphs list the _gvid of
+// their subgraphs, nodes and edges, where the nodes of a subgraph include
+// those of nested subgraphs, and an edge is listed by each subgraph containing
+// both its nodes. Edges between subgraphs are expanded into edges between
+// their nodes, and edge ports are stored in the tailport and headport
+// attributes. HTML strings are stored with their angle brackets.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	var subs []string
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, sub := range childSubGraphs(g, parent) {
+			subs = append(subs, sub)
+			walk(sub)
+		}
+	}
+	walk(g.Name)
+	ids := make(map[string]int)
+	for i, sub := range subs {
+		ids[sub] = i
+	}
+	objects := make([]interface{}, len(subs))
+	for i, node := range g.Nodes.Nodes {
+		ids[node.Name] = len(subs) + i
+		obj := jsonAttrs(node.Attrs)
+		obj["_gvid"] = len(subs) + i
+		obj["name"] = node.Name
+		objects = append(objects, obj)
+	}
+
+	// Nodes of subgraphs.
+	members := make(map[string]map[string]bool)
+	for _, sub := range subs {
+		members[sub] = make(map[string]bool)
+		for _, name := range subGraphNodes(g, sub) {
+			members[sub][name] = true
+		}
+	}
+
+	// Edges.
+	var edges []interface{}
+	subEdges := make(map[string][]int)
+	for _, edge := range g.Edges.Edges {
+		for _, src := range edgeEndpoints(g, edge.Src) {
+			for _, dst := range edgeEndpoints(g, edge.Dst) {
+				obj := jsonAttrs(edge.Attrs)
+				if edge.SrcPort != "" && src == edge.Src {
+					obj["tailport"] = strings.TrimPrefix(edge.SrcPort, ":")
+				}
+				if edge.DstPort != "" && dst == edge.Dst {
+					obj["headport"] = strings.TrimPrefix(edge.DstPort, ":")
+				}
+				obj["_gvid"] = len(edges)
+				obj["tail"] = ids[src]
+				obj["head"] = ids[dst]
+				for _, sub := range subs {
+					if members[sub][src] && members[sub][dst] {
+						subEdges[sub] = append(subEdges[sub], len(edges))
+					}
+				}
+				edges = append(edges, obj)
+			}
+		}
+	}
+
+	// Subgraphs.
+	for i, sub := range subs {
+		obj := jsonAttrs(g.SubGraphs.SubGraphs[sub].Attrs)
+		obj["_gvid"] = i
+		obj["name"] = sub
+		setJSONIds(obj, "subgraphs", childSubGraphs(g, sub), ids)
+		setJSONIds(obj, "nodes", subGraphNodes(g, sub), ids)
+		if len(subEdges[sub]) > 0 {
+			obj["edges"] = subEdges[sub]
+		}
+		objects[i] = obj
+	}
+
+	root := jsonAttrs(g.Attrs)
+	root["name"] = g.Name
+	root["directed"] = g.Directed
+	root["strict"] = g.Strict
+	root["_subgraph_cnt"] = len(subs)
+	setJSONIds(root, "subgraphs", childSubGraphs(g, g.Name), ids)
+	if len(objects) > 0 {
+		root["objects"] = objects
+	}
+	if len(edges) > 0 {
+		root["edges"] = edges
+	}
+	return json.Marshal(root)
+}
+
+// jsonAttrs returns a JSON object holding the given attributes.
+func jsonAttrs(attrs Attrs) map[string]interface{} {
+	obj := make(map[string]interface{})
+	for name, value := range attrs {
+		obj[name] = value
+	}
+	return obj
+}
+
+// setJSONIds stores the _gvid of the given subgraphs or nodes in the named
+// array of the JSON object, unless empty.
+func setJSONIds(obj map[string]interface{}, key string, names []string, ids map[string]int) {
+	if len(names) == 0 {
+		return
+	}
+	var list []int
+	for _, name := range names {
+		list = append(list, ids[name])
+	}
+	obj[key] = list
+}
+
+// UnmarshalJSON sets the graph to the given graph in the JSON format of
+// Graphviz, as with ReadJSON.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	parsed, err := ReadJSON(data)
+	if err != nil {
+		return err
+	}
+	*g = *parsed
+	return nil
+}
+
+// ReadJSON reads a graph in the JSON format of Graphviz, as output by
+// `dot -Tjson0` or `dot -Tjson`. Values other than strings, e.g. the xdot
+// drawing operations of `dot -Tjson`, are ignored, and the tailport and
+// headport attributes of edges are stored as their ports. Nodes are added to
+// the innermost subgraphs listing them. Strings enclosing well-formed markup in
+// angle brackets, e.g. "<<b>bold</b>>", are read as HTML strings.
+func ReadJSON(data []byte) (*Graph, error) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	g := NewGraph()
+	if err := readJSONFields(root, map[string]interface{}{"name": &g.Name, "directed": &g.Directed, "strict": &g.Strict}); err != nil {
+		return nil, fmt.Errorf("graph: %v", err)
+	}
+	g.Attrs, g.HTMLAttrs = readJSONAttrs(root)
+
+	// Objects, by _gvid.
+	var objs []map[string]json.RawMessage
+	var subCount int
+	if err := readJSONFields(root, map[string]interface{}{"objects": &objs, "_subgraph_cnt": &subCount}); err != nil {
+		return nil, fmt.Errorf("graph %s: %v", Quote(g.Name), err)
+	}
+	type object struct {
+		name        string
+		subs, nodes []int
+		attrs       Attrs
+		html        HTMLAttrs
+		// Whether the object is a subgraph, or a node of a subgraph.
+		isSub, inSub bool
+	}
+	objects := make(map[int]*object)
+	for i, obj := range objs {
+		o := &object{}
+		o.attrs, o.html = readJSONAttrs(obj)
+		id := i
+		if err := readJSONFields(obj, map[string]interface{}{"_gvid": &id, "name": &o.name, "subgraphs": &o.subs, "nodes": &o.nodes}); err != nil {
+			return nil, fmt.Errorf("object %d: %v", i, err)
+		}
+		if _, ok := objects[id]; ok {
+			return nil, fmt.Errorf("object %d: duplicate _gvid %d", i, id)
+		}
+		o.isSub = id < subCount
+		objects[id] = o
+	}
+	ids := make([]int, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	// lookup returns the object of the given _gvid, which must be a subgraph
+	// or node as specified.
+	lookup := func(id int, isSub bool) (*object, error) {
+		o, ok := objects[id]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("no object with _gvid %d", id)
+		case o.isSub != isSub && isSub:
+			return nil, fmt.Errorf("object %s with _gvid %d is not a subgraph", Quote(o.name), id)
+		case o.isSub != isSub:
+			return nil, fmt.Errorf("object %s with _gvid %d is not a node", Quote(o.name), id)
+		}
+		return o, nil
+	}
+
+	// Subgraphs, with the nodes listed by their nested subgraphs.
+	var rootSubs []int
+	if err := readJSONFields(root, map[string]interface{}{"subgraphs": &rootSubs}); err != nil {
+		return nil, fmt.Errorf("graph %s: %v", Quote(g.Name), err)
+	}
+	nested := make(map[int]map[int]bool)
+	var addSubs func(parent string, subs []int, depth int) (map[int]bool, error)
+	addSubs = func(parent string, subs []int, depth int) (map[int]bool, error) {
+		listed := make(map[int]bool)
+		if depth > len(objects) {
+			return nil, fmt.Errorf("subgraph %s: cyclic nesting of subgraphs", Quote(parent))
+		}
+		for _, id := range subs {
+			o, err := lookup(id, true)
+			if err != nil {
+				return nil, fmt.Errorf("subgraph %s: %v", Quote(parent), err)
+			}
+			g.addSubGraph(parent, o.name, o.attrs, o.html)
+			inner, err := addSubs(o.name, o.subs, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			nested[id] = inner
+			for _, node := range o.nodes {
+				listed[node] = true
+			}
+			for node := range inner {
+				listed[node] = true
+			}
+		}
+		return listed, nil
+	}
+	if _, err := addSubs(g.Name, rootSubs, 0); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if o := objects[id]; o.isSub && !g.IsSubGraph(o.name) {
+			g.addSubGraph(g.Name, o.name, o.attrs, o.html)
+		}
+	}
+
+	// Nodes.
+	for _, id := range ids {
+		if o := objects[id]; !o.isSub {
+			g.Nodes.Add(&Node{Name: o.name, Attrs: o.attrs, HTMLAttrs: o.html})
+		}
+	}
+	for _, id := range ids {
+		o := objects[id]
+		if !o.isSub {
+			continue
+		}
+		for _, node := range o.nodes {
+			n, err := lookup(node, false)
+			if err != nil {
+				return nil, fmt.Errorf("subgraph %s: %v", Quote(o.name), err)
+			}
+			if !nested[id][node] {
+				g.Relations.Add(o.name, n.name)
+				n.inSub = true
+			}
+		}
+	}
+	for _, id := range ids {
+		if o := objects[id]; !o.isSub && !o.inSub {
+			g.Relations.Add(g.Name, o.name)
+		}
+	}
+
+	// Edges.
+	var edges []map[string]json.RawMessage
+	if err := readJSONFields(root, map[string]interface{}{"edges": &edges}); err != nil {
+		return nil, fmt.Errorf("graph %s: %v", Quote(g.Name), err)
+	}
+	for i, obj := range edges {
+		var tail, head int
+		if _, ok := obj["tail"]; !ok {
+			return nil, fmt.Errorf("edge %d: missing tail", i)
+		}
+		if _, ok := obj["head"]; !ok {
+			return nil, fmt.Errorf("edge %d: missing head", i)
+		}
+		if err := readJSONFields(obj, map[string]interface{}{"tail": &tail, "head": &head}); err != nil {
+			return nil, fmt.Errorf("edge %d: %v", i, err)
+		}
+		src, err := lookup(tail, false)
+		if err != nil {
+			return nil, fmt.Errorf("edge %d: %v", i, err)
+		}
+		dst, err := lookup(head, false)
+		if err != nil {
+			return nil, fmt.Errorf("edge %d: %v", i, err)
+		}
+		edge := &Edge{Src: src.name, Dst: dst.name, Dir: g.Directed}
+		edge.Attrs, edge.HTMLAttrs = readJSONAttrs(obj)
+		if port, ok := edge.Attrs["tailport"]; ok {
+			edge.SrcPort = ":" + port
+			delete(edge.Attrs, "tailport")
+		}
+		if port, ok := edge.Attrs["headport"]; ok {
+			edge.DstPort = ":" + port
+			delete(edge.Attrs, "headport")
+		}
+		g.Edges.Add(edge)
+	}
+	linkNodes(g)
+	return g, nil
+}
+
+// readJSONFields decodes the present keys of the JSON object into the given
+// values.
+func readJSONFields(obj map[string]json.RawMessage, fields map[string]interface{}) error {
+	for key, v := range fields {
+		raw, ok := obj[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("invalid %q; %v", key, err)
+		}
+	}
+	return nil
+}
+
+// readJSONAttrs returns the attributes of the JSON object; i.e. its string
+// values other than those of the JSON format, and the names of those which are
+// HTML strings.
+func readJSONAttrs(obj map[string]json.RawMessage) (Attrs, HTMLAttrs) {
+	attrs := NewAttrs()
+	var html HTMLAttrs
+	for key, raw := range obj {
+		var value string
+		if jsonKeys[key] || json.Unmarshal(raw, &value) != nil {
+			continue
+		}
+		attrs[key] = value
+		html.set(key, isHtml(value))
+	}
+	return attrs, html
+}
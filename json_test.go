@@ -0,0 +1,153 @@
+package dot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_outer {
+			label="outer";
+			a;
+			subgraph cluster_inner { b [shape=box]; c; }
+		}
+		a:e -> b [color=red];
+		d;
+	}`))
+	check(t, err)
+	g.AddEdge("d", "cluster_inner", true, NewAttrs())
+	data, err := json.Marshal(g)
+	check(t, err)
+
+	var root struct {
+		Name     string
+		Directed bool
+		RankDir  string `json:"rankdir"`
+		SubCount int    `json:"_subgraph_cnt"`
+		Subs     []int  `json:"subgraphs"`
+		Objects  []struct {
+			Gvid  int `json:"_gvid"`
+			Name  string
+			Label string
+			Subs  []int `json:"subgraphs"`
+			Nodes []int
+			Edges []int
+		}
+		Edges []struct {
+			Gvid     int `json:"_gvid"`
+			Tail     int
+			Head     int
+			Color    string
+			TailPort string `json:"tailport"`
+		}
+	}
+	check(t, json.Unmarshal(data, &root))
+	assert(t, "name", root.Name, "G")
+	assert(t, "directed", root.Directed, true)
+	assert(t, "attr", root.RankDir, "LR")
+	assert(t, "subgraph count", root.SubCount, 2)
+	assert(t, "root subgraphs", len(root.Subs), 1)
+	assert(t, "objects", len(root.Objects), 6)
+	outer, inner := root.Objects[0], root.Objects[1]
+	assert(t, "outer", outer.Name, "cluster_outer")
+	assert(t, "outer label", outer.Label, "outer")
+	assert(t, "nested", len(outer.Subs), 1)
+	assert(t, "nested gvid", outer.Subs[0], 1)
+	assert(t, "inner", inner.Name, "cluster_inner")
+	assert(t, "outer nodes", len(outer.Nodes), 3)
+	assert(t, "inner nodes", len(inner.Nodes), 2)
+	for i, obj := range root.Objects {
+		assert(t, "gvid", obj.Gvid, i)
+	}
+	// d -> cluster_inner is expanded into an edge to each of its nodes.
+	assert(t, "edges", len(root.Edges), 3)
+	e := root.Edges[0]
+	assert(t, "tail", root.Objects[e.Tail].Name, "a")
+	assert(t, "head", root.Objects[e.Head].Name, "b")
+	assert(t, "edge attr", e.Color, "red")
+	assert(t, "tailport", e.TailPort, "e")
+	assert(t, "outer edges", len(outer.Edges), 1)
+	assert(t, "inner edges", len(inner.Edges), 0)
+	assert(t, "expanded", root.Objects[root.Edges[2].Head].Name, "c")
+
+	// Round trip.
+	h, err := ReadJSON(data)
+	check(t, err)
+	assert(t, "round trip", Equal(expand(g), h), true)
+}
+
+// expand returns the graph with edges between subgraphs expanded into edges
+// between their nodes.
+func expand(g *Graph) *Graph {
+	c := g.Clone()
+	c.Edges = NewEdges()
+	for _, edge := range g.Edges.Edges {
+		for _, src := range edgeEndpoints(g, edge.Src) {
+			for _, dst := range edgeEndpoints(g, edge.Dst) {
+				c.AddPortEdge(src, edge.SrcPort, dst, edge.DstPort, edge.Dir, edge.Attrs.Copy())
+			}
+		}
+	}
+	return c
+}
+
+func TestReadJSON(t *testing.T) {
+	// Output of `dot -Tjson` with the drawing operations abridged.
+	const src = `{
+	  "name": "G",
+	  "directed": true,
+	  "strict": false,
+	  "bb": "0,0,62,124",
+	  "_draw_": [{"op": "c", "grad": "none", "color": "#fffffe00"}],
+	  "_subgraph_cnt": 1,
+	  "objects": [
+	    {"_gvid": 0, "name": "cluster_x", "label": "x", "nodes": [1], "edges": []},
+	    {"_gvid": 1, "name": "a", "label": "\\N", "pos": "27,90", "_ldraw_": []},
+	    {"_gvid": 2, "name": "b", "label": "<<b>b</b>>"}
+	  ],
+	  "edges": [
+	    {"_gvid": 0, "tail": 1, "head": 2, "headport": "n", "weight": "2", "_draw_": []}
+	  ]
+	}`
+	g, err := ReadJSON([]byte(src))
+	check(t, err)
+	assert(t, "name", g.Name, "G")
+	assert(t, "directed", g.Directed, true)
+	assert(t, "graph attr", g.Attrs["bb"], "0,0,62,124")
+	assert(t, "drawing ignored", g.Attrs["_draw_"], "")
+	assert(t, "subgraph", g.SubGraphs.SubGraphs["cluster_x"].Attrs["label"], "x")
+	assert(t, "subgraph parent", g.SubGraphs.SubGraphs["cluster_x"].Parent, "G")
+	assert(t, "member", g.Relations.ChildToParents["a"]["cluster_x"], true)
+	assert(t, "root member", g.Relations.ChildToParents["b"]["G"], true)
+	assert(t, "escString", g.Nodes.Lookup["a"].Attrs["label"], `\N`)
+	assert(t, "html", g.Nodes.Lookup["b"].Attrs["label"], "<<b>b</b>>")
+	assert(t, "html attribute", g.Nodes.Lookup["b"].HTMLAttrs["label"], true)
+	assert(t, "not html", g.Nodes.Lookup["a"].HTMLAttrs["label"], false)
+	edge := g.Edges.Edges[0]
+	assert(t, "edge", edge.Src+"->"+edge.Dst, "a->b")
+	assert(t, "port", edge.DstPort, ":n")
+	assert(t, "port attr", edge.Attrs["headport"], "")
+	assert(t, "edge attr", edge.Attrs["weight"], "2")
+	assert(t, "linked", len(g.Nodes.Lookup["b"].Preds), 1)
+
+	var u Graph
+	check(t, json.Unmarshal([]byte(src), &u))
+	assert(t, "unmarshal", Equal(g, &u), true)
+
+	invalid := []string{
+		`[]`,
+		`{"objects": [{"name": "a"}], "edges": [{"tail": 0, "head": 1}]}`,
+		`{"objects": [{"name": "a"}], "edges": [{"tail": 0}]}`,
+		`{"_subgraph_cnt": 1, "objects": [{"name": "s"}], "edges": [{"tail": 0, "head": 0}]}`,
+		`{"objects": [{"name": "a"}], "subgraphs": [0]}`,
+		`{"objects": [{"_gvid": 0, "name": "a"}, {"_gvid": 0, "name": "b"}]}`,
+		`{"directed": "yes"}`,
+	}
+	for _, src := range invalid {
+		if _, err := ReadJSON([]byte(src)); err == nil {
+			t.Errorf("%s: expected error", src)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package dot
+
+// This file defines a linter of Graphviz attributes and edges.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mewspring/dot/ast"
+	"github.com/mewspring/dot/scanner"
+	"github.com/mewspring/dot/token"
+)
+
+// Issue is a problem reported by the linter.
+type Issue struct {
+	// Source position of the problem; invalid if unknown.
+	Pos token.Position
+	// Element containing the problem, e.g. `node "a"`.
+	Elem string
+	// Description of the problem.
+	Msg string
+	// Port name of the problem, used to locate port issues.
+	port string
+}
+
+func (issue *Issue) String() string {
+	if issue.Pos.IsValid() {
+		return fmt.Sprintf("%d:%d: %s: %s", issue.Pos.Line, issue.Pos.Column, issue.Elem, issue.Msg)
+	}
+	return fmt.Sprintf("%s: %s", issue.Elem, issue.Msg)
+}
+
+// Lint checks the attributes of the graph, its subgraphs, nodes and edges
+// against the Graphviz attribute schema, and the endpoints of its edges
+// against its nodes and subgraphs. Ports of record nodes are checked using
+// CheckPorts. Reported issues have no source positions;
+// use LintSource to lint DOT files.
+//
+// The attributes of the root graph may also apply to subgraphs, as they act as
+// defaults of the subgraphs. Attributes of subgraphs equal to those of their
+// enclosing graph are inherited, as the analyser copies the attributes of the
+// enclosing graph into subgraphs, and are not checked.
+//
+// Edges referring to missing nodes are reported. The analyser adds each
+// endpoint of an edge as a node, so these only occur in graphs built using
+// e.g. AddEdge.
+func Lint(g *Graph) []*Issue {
+	var issues []*Issue
+	add := func(elem, msg string) {
+		issues = append(issues, &Issue{Elem: elem, Msg: msg})
+	}
+	for _, name := range g.Attrs.SortedNames() {
+		if msg := lintAttr(ContextGraph|ContextSubGraph, name, g.Attrs[name], g.HTMLAttrs[name]); msg != "" {
+			add(graphElem(g.Name), msg)
+		}
+	}
+	for _, sub := range g.SubGraphs.Sorted() {
+		parent := g.Attrs
+		if p, ok := g.SubGraphs.SubGraphs[sub.Parent]; ok {
+			parent = p.Attrs
+		}
+		for _, name := range sub.Attrs.SortedNames() {
+			if value, ok := parent[name]; ok && value == sub.Attrs[name] {
+				continue
+			}
+			if msg := lintAttr(SubGraphContext(sub.Name), name, sub.Attrs[name], sub.HTMLAttrs[name]); msg != "" {
+				add("subgraph "+Quote(sub.Name), msg)
+			}
+		}
+	}
+	for _, node := range g.Nodes.Nodes {
+		for _, name := range node.Attrs.SortedNames() {
+			if msg := lintAttr(ContextNode, name, node.Attrs[name], node.HTMLAttrs[name]); msg != "" {
+				add("node "+Quote(node.Name), msg)
+			}
+		}
+	}
+	var names []string
+	for _, node := range g.Nodes.Nodes {
+		names = append(names, node.Name)
+	}
+	for _, edge := range g.Edges.Edges {
+		elem := "edge " + edgeString(edge)
+		for _, name := range []string{edge.Src, edge.Dst} {
+			if !g.IsNode(name) && !g.IsSubGraph(name) {
+				add(elem, "undeclared node "+Quote(name)+suggest(name, names))
+			}
+		}
+		for _, name := range edge.Attrs.SortedNames() {
+			if msg := lintAttr(ContextEdge, name, edge.Attrs[name], edge.HTMLAttrs[name]); msg != "" {
+				add(elem, msg)
+			}
+		}
+	}
+	return append(issues, CheckPorts(g)...)
+}
+
+// graphElem returns a description of the named root graph.
+func graphElem(name string) string {
+	if name == "" {
+		return "graph"
+	}
+	return "graph " + Quote(name)
+}
+
+// lintAttr returns a description of the problem with the given attribute in
+// the given context, whose value is an HTML string if html is true, or the
+// empty string if valid.
+func lintAttr(ctx Context, name, value string, html bool) string {
+	if _, ok := LookupAttr(name); !ok {
+		return fmt.Sprintf("unknown attribute %q%s", name, suggest(name, AttrNames()))
+	}
+	if err := validateAttr(ctx, name, value, html); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// suggest returns a "did you mean" suggestion of the candidate most similar to
+// name, or the empty string if none is similar enough.
+func suggest(name string, candidates []string) string {
+	best, bestDist := "", 0
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+		d := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if best == "" || d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	// Permit no edits for very short names, one for short names, and two for
+	// longer ones.
+	limit := 0
+	switch {
+	case len(name) > 5:
+		limit = 2
+	case len(name) >= 3:
+		limit = 1
+	}
+	if best == "" || bestDist > limit {
+		return ""
+	}
+	return fmt.Sprintf("; did you mean %q?", best)
+}
+
+// editDistance returns the optimal string alignment distance between a and b;
+// i.e. the number of insertions, deletions, substitutions and transpositions
+// of adjacent characters required to transform a into b.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// minInt returns the smallest of the given integers.
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
+
+// LintSource parses the given DOT source and checks the attributes of each
+// statement against the Graphviz attribute schema. Issues are reported once
+// per attribute occurrence, along with its source position.
+//
+// Edges referring to nodes without a node statement are reported if their names
+// are close to those of nodes declared using node statements, as these are
+// likely misspelled.
+func LintSource(buf []byte) ([]*Issue, error) {
+	st, err := Parse(buf)
+	if err != nil {
+		return nil, err
+	}
+	l := &linter{tokens: newTokenStream(buf), declared: make(map[string]bool)}
+	l.stmts(st.StmtList, ContextGraph|ContextSubGraph, graphElem(unquoteId(st.Id.String())))
+	declared := sortedKeys(l.declared)
+	for _, ref := range l.refs {
+		if l.declared[ref.name] {
+			continue
+		}
+		if s := suggest(ref.name, declared); s != "" {
+			msg := "undeclared node " + Quote(ref.name) + s
+			l.issues = append(l.issues, &Issue{Pos: ref.pos, Elem: ref.elem, Msg: msg})
+		}
+	}
+	// Locate the ports of port issues, which are found in edge order.
+	ports := newTokenStream(buf)
+	for _, issue := range CheckPorts(NewAnalysedGraph(st)) {
+		issue.Pos = ports.findPort(issue.port)
+		l.issues = append(l.issues, issue)
+	}
+	sortIssues(l.issues)
+	return l.issues, nil
+}
+
+// LintFile reads and lints the given DOT file.
+func LintFile(path string) ([]*Issue, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LintSource(buf)
+}
+
+// sortIssues sorts issues by source position, retaining the order of issues
+// at the same position.
+func sortIssues(issues []*Issue) {
+	for i := 1; i < len(issues); i++ {
+		for j := i; j > 0 && issues[j].Pos.Offset < issues[j-1].Pos.Offset; j-- {
+			issues[j], issues[j-1] = issues[j-1], issues[j]
+		}
+	}
+}
+
+// linter holds the state of linting DOT source.
+type linter struct {
+	tokens *tokenStream
+	issues []*Issue
+	// Names of nodes declared using node statements.
+	declared map[string]bool
+	// Edge endpoints referring to nodes.
+	refs []nodeRef
+}
+
+// nodeRef is a reference to a node by an edge endpoint.
+type nodeRef struct {
+	name string
+	elem string
+	pos  token.Position
+}
+
+// stmts lints the given statements of a graph or subgraph with the given
+// context.
+func (l *linter) stmts(stmts ast.StmtList, ctx Context, elem string) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.NodeStmt:
+			id := s.NodeId.Id.String()
+			l.tokens.find(id)
+			name := unquoteId(id)
+			l.declared[name] = true
+			l.attrs(s.Attrs, ContextNode, "node "+Quote(name))
+		case *ast.EdgeStmt:
+			edge := "edge " + locString(s.Source)
+			for _, rh := range s.EdgeRHS {
+				edge += rh.Op.String() + locString(rh.Destination)
+			}
+			l.endpoint(s.Source, edge)
+			for _, rh := range s.EdgeRHS {
+				l.endpoint(rh.Destination, edge)
+			}
+			l.attrs(s.Attrs, ContextEdge, edge)
+		case ast.NodeAttrs:
+			l.attrs(ast.AttrList(s), ContextNode, "node defaults")
+		case ast.EdgeAttrs:
+			l.attrs(ast.AttrList(s), ContextEdge, "edge defaults")
+		case ast.GraphAttrs:
+			l.attrs(ast.AttrList(s), ctx, elem)
+		case *ast.SubGraph:
+			l.subGraph(s)
+		case *ast.Attr:
+			l.attr(s, ctx, elem)
+		}
+	}
+}
+
+// subGraph lints the given subgraph.
+func (l *linter) subGraph(sub *ast.SubGraph) {
+	name := unquoteId(sub.Id.String())
+	elem := "anonymous subgraph"
+	if !isAnonymous(name) {
+		elem = "subgraph " + Quote(name)
+	}
+	l.stmts(sub.StmtList, SubGraphContext(name), elem)
+}
+
+// locString returns a short description of the given edge endpoint.
+func locString(loc ast.Location) string {
+	if sub, ok := loc.(*ast.SubGraph); ok {
+		if isAnonymous(unquoteId(sub.Id.String())) {
+			return "subgraph"
+		}
+		return "subgraph " + sub.Id.String()
+	}
+	return loc.String()
+}
+
+// endpoint records the given edge endpoint, or lints it if a subgraph.
+func (l *linter) endpoint(loc ast.Location, elem string) {
+	switch loc := loc.(type) {
+	case *ast.NodeId:
+		id := loc.Id.String()
+		l.refs = append(l.refs, nodeRef{name: unquoteId(id), elem: elem, pos: l.tokens.find(id)})
+	case *ast.SubGraph:
+		l.subGraph(loc)
+	}
+}
+
+// attrs lints the given attribute list in the given context.
+func (l *linter) attrs(attrs ast.AttrList, ctx Context, elem string) {
+	for _, alist := range attrs {
+		for _, attr := range alist {
+			l.attr(attr, ctx, elem)
+		}
+	}
+}
+
+// attr lints the given attribute in the given context.
+func (l *linter) attr(attr *ast.Attr, ctx Context, elem string) {
+	name := attr.Field.String()
+	pos := l.tokens.findAttr(name)
+	value := attr.Value.String()
+	if msg := lintAttr(ctx, unquoteId(name), unquoteId(value), isHtmlId(value)); msg != "" {
+		l.issues = append(l.issues, &Issue{Pos: pos, Elem: elem, Msg: msg})
+	}
+}
+
+// tokenStream locates the source positions of IDs, which are not recorded by
+// the AST. As the AST is linted in source order, IDs are searched for from the
+// position of the previously located ID.
+type tokenStream struct {
+	lits []string
+	pos  []token.Position
+	// Index of the next token to search from.
+	cur int
+}
+
+// newTokenStream returns a token stream of the given DOT source.
+func newTokenStream(buf []byte) *tokenStream {
+	s := &tokenStream{}
+	lex := &scanner.Scanner{}
+	lex.Init(buf, token.DOTTokens)
+	for {
+		tok, pos := lex.Scan()
+		if tok.Type == token.EOF {
+			break
+		}
+		s.lits = append(s.lits, string(tok.Lit))
+		s.pos = append(s.pos, pos)
+	}
+	return s
+}
+
+// lit returns the literal of the i:th token, or the empty string if out of
+// range.
+func (s *tokenStream) lit(i int) string {
+	if i < 0 || i >= len(s.lits) {
+		return ""
+	}
+	return s.lits[i]
+}
+
+// find returns the position of the next token with the given literal, or an
+// invalid position if not found.
+func (s *tokenStream) find(lit string) token.Position {
+	return s.search(func(i int) bool {
+		return s.lits[i] == lit
+	})
+}
+
+// findAttr returns the position of the next attribute with the given name, or
+// an invalid position if not found.
+func (s *tokenStream) findAttr(name string) token.Position {
+	return s.search(func(i int) bool {
+		if s.lits[i] != name {
+			return false
+		}
+		switch s.lit(i + 1) {
+		case "=":
+			return true
+		case ",", ";", "]":
+			// Attribute without value.
+			prev := s.lit(i - 1)
+			return prev == "[" || prev == "," || prev == ";"
+		}
+		return false
+	})
+}
+
+// findPort returns the position of the next port with the given unquoted
+// name, or an invalid position if not found.
+func (s *tokenStream) findPort(port string) token.Position {
+	return s.search(func(i int) bool {
+		return unquoteId(s.lits[i]) == port && s.lit(i-1) == ":"
+	})
+}
+
+// search returns the position of the next token satisfying f, or an invalid
+// position if not found.
+func (s *tokenStream) search(f func(i int) bool) token.Position {
+	for i := s.cur; i < len(s.lits); i++ {
+		if f(i) {
+			s.cur = i + 1
+			return s.pos[i]
+		}
+	}
+	return token.Position{}
+}
+
+// CheckPorts reports edges referencing ports of nodes which are not declared by
+// their labels; i.e. by the fields of record labels, or by the PORT attributes
+// of HTML-like labels. Compass points, e.g. ":n", are valid ports of any node.
+func CheckPorts(g *Graph) []*Issue {
+	var issues []*Issue
+	for _, edge := range g.Edges.Edges {
+		for _, end := range []struct{ name, port string }{{edge.Src, edge.SrcPort}, {edge.Dst, edge.DstPort}} {
+			if end.port == "" {
+				continue
+			}
+			node, ok := g.Nodes.Lookup[end.name]
+			if !ok {
+				continue
+			}
+			elem := "edge " + edgeString(edge)
+			ports, kind, err := node.labelPorts()
+			if err != nil {
+				issues = append(issues, &Issue{Elem: elem, Msg: err.Error()})
+				continue
+			}
+			if kind == "" {
+				continue
+			}
+			port, compass := splitPort(end.port)
+			if compass == "" && contains(compassPoints, port) {
+				continue
+			}
+			if !contains(ports, port) {
+				msg := fmt.Sprintf("port %q not declared by %s label of node %s%s", port, kind, Quote(node.Name), suggest(port, ports))
+				issues = append(issues, &Issue{Elem: elem, Msg: msg, port: port})
+			}
+		}
+	}
+	return issues
+}
+
+// labelPorts returns the ports declared by the label of the node, along with
+// the kind of label; i.e. "HTML" or "record", or the empty string if neither.
+func (n *Node) labelPorts() ([]string, string, error) {
+	html, err := n.HTMLLabel()
+	if err != nil {
+		return nil, "HTML", fmt.Errorf("node %s: %v", Quote(n.Name), err)
+	}
+	if html != nil {
+		return html.Ports(), "HTML", nil
+	}
+	if !n.isRecord() {
+		return nil, "", nil
+	}
+	record, err := n.Record()
+	if err != nil {
+		return nil, "record", fmt.Errorf("node %s: %v", Quote(n.Name), err)
+	}
+	return record.Ports(), "record", nil
+}
@@ -0,0 +1,93 @@
+package dot
+
+import "testing"
+
+func TestLint(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		a [lable="x", shape=box];
+		b [rankdir=TB, color="#12"];
+		a -> b [arrowhead=arrow, penwidth=2];
+	}`))
+	check(t, err)
+	g.AddEdge("b", "c", true, nil)
+	var got []string
+	for _, issue := range Lint(g) {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`node a: unknown attribute "lable"; did you mean "label"?`,
+		`node b: invalid value "#12" of attribute "color"; expected color or colorList`,
+		`node b: attribute "rankdir" does not apply to N (valid contexts: G)`,
+		`edge a->b: invalid value of attribute "arrowhead"; invalid arrowType "arrow"`,
+		`edge b->c: undeclared node c`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d issues, got %q", len(want), got)
+	}
+	for i := range want {
+		assert(t, "issue", got[i], want[i])
+	}
+
+	// Transparent colours are valid.
+	issues, err := LintSource([]byte(`digraph { bgcolor=transparent; a [color=none] }`))
+	check(t, err)
+	if len(issues) != 0 {
+		t.Errorf("unexpected issues %v", issues)
+	}
+
+	// Attributes inherited by subgraphs are not checked.
+	g, err = Read([]byte(`digraph { graph [rankdir=LR]; subgraph cluster_x { a } }`))
+	check(t, err)
+	if issues := Lint(g); len(issues) != 0 {
+		t.Errorf("unexpected issues %v", issues)
+	}
+}
+
+func TestLintSource(t *testing.T) {
+	issues, err := LintSource([]byte(`digraph G {
+	node [shape=blob];
+	subgraph cluster_0 {
+		rankdir=LR;
+		label="c";
+		start;
+	}
+	start [lable="Start"];
+	finish;
+	start -> finsh [penWidth=2];
+	start -> finish [pos="1,2 3,4 e,5,6"];
+}`))
+	check(t, err)
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`2:8: node defaults: invalid value of attribute "shape"; invalid shape "blob"`,
+		`4:3: subgraph cluster_0: attribute "rankdir" does not apply to C (valid contexts: G)`,
+		`8:9: node start: unknown attribute "lable"; did you mean "label"?`,
+		`10:11: edge start->finsh: undeclared node finsh; did you mean "finish"?`,
+		`10:18: edge start->finsh: unknown attribute "penWidth"; did you mean "penwidth"?`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d issues, got %q", len(want), got)
+	}
+	for i := range want {
+		assert(t, "issue", got[i], want[i])
+	}
+
+	// Nodes only referred to by edges are reported if misspelled.
+	issues, err = LintSource([]byte(`digraph { a [shape=box]; a -> b }`))
+	check(t, err)
+	if len(issues) != 0 {
+		t.Errorf("unexpected issues %v", issues)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	names := AttrNames()
+	assert(t, "transposition", suggest("lable", names), `; did you mean "label"?`)
+	assert(t, "case", suggest("fontSize", names), `; did you mean "fontsize"?`)
+	assert(t, "missing letter", suggest("fillcolr", names), `; did you mean "fillcolor"?`)
+	assert(t, "no suggestion", suggest("zzzzzz", names), "")
+}
@@ -0,0 +1,341 @@
+package dot
+
+// This file defines the marshalling of Go values to graphs, depicting data
+// structures as in testdata/datastruct.gv.txt.
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Marshal returns a graph depicting the given Go value. Structs, slices,
+// arrays and maps are depicted by record nodes, titled by their type, with a
+// field for each struct field, element or map entry. Fields of basic values,
+// e.g. numbers and strings, show their values, and other fields have a port
+// with an edge to the node depicting their value. Pointers are followed, and
+// values referenced by several pointers are depicted once. Unexported struct
+// fields are omitted, and values implementing fmt.Stringer without exported
+// fields, e.g. time.Time, are shown using their String method.
+//
+// The depiction of struct fields is controlled by comma-separated options of
+// their "dot" struct tags, as in `dot:"label,omit,shape=box"`. A leading name,
+// e.g. the attribute of the field used by Unmarshal, names the field, and the
+// options of Unmarshal are ignored.
+//
+//	"-"          omit the field
+//	label        title the node of the struct by the value of the field
+//	omit         omit the field from the record; edges start at the node
+//	omitempty    omit the field if it has the zero value
+//	name=id      show the field with the given name
+//	attr=value   set the Graphviz attribute of the edge of the field, if
+//	             valid for edges, or otherwise of the node of the struct
+//
+// Nil fields have no edge, and the edge attributes of nil fields are thus not
+// set.
+//
+// Nodes with a shape other than record or Mrecord are labelled by their title.
+func Marshal(v interface{}) (*Graph, error) {
+	m := &marshaller{g: NewGraph(), nodes: make(map[nodeKey]string)}
+	m.g.SetDir(true)
+	m.g.Attrs["rankdir"] = "LR"
+	val := reflect.ValueOf(v)
+	if val.IsValid() && !isNilValue(val) {
+		if _, err := m.value(val); err != nil {
+			return nil, err
+		}
+	}
+	linkNodes(m.g)
+	return m.g, nil
+}
+
+// marshaller holds the state of marshalling a Go value to a graph.
+type marshaller struct {
+	g *Graph
+	// Names of the nodes depicting addressable values.
+	nodes map[nodeKey]string
+}
+
+// nodeKey identifies an addressable value, or the elements of a slice or map.
+type nodeKey struct {
+	addr uintptr
+	typ  reflect.Type
+	len  int
+}
+
+// fieldOpts specifies the depiction of a struct field.
+type fieldOpts struct {
+	skip      bool
+	label     bool
+	omit      bool
+	omitEmpty bool
+	name      string
+	attrs     [][2]string
+}
+
+// parseFieldTag parses the options of the given "dot" struct tag.
+func parseFieldTag(tag string) (fieldOpts, error) {
+	var opts fieldOpts
+	if tag == "" {
+		return opts, nil
+	}
+	for i, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		if j := strings.Index(opt, "="); j != -1 {
+			name, value := opt[:j], opt[j+1:]
+			if name == "name" {
+				opts.name = value
+			} else {
+				opts.attrs = append(opts.attrs, [2]string{name, value})
+			}
+			continue
+		}
+		switch opt {
+		case "-":
+			opts.skip = true
+		case "label":
+			opts.label = true
+		case "omit":
+			opts.omit = true
+		case "omitempty":
+			opts.omitEmpty = true
+		case "":
+		default:
+			switch {
+			case i == 0:
+				// Name of the field, e.g. the attribute used by Unmarshal.
+				opts.name = opt
+			case unmarshalOpts[opt]:
+			default:
+				return opts, fmt.Errorf("unknown option %q", opt)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// entry is a field, element or map entry of a value depicted by a record node.
+type entry struct {
+	name  string
+	value reflect.Value
+	opts  fieldOpts
+}
+
+// value adds a node depicting the given value, unless already added, and
+// returns its name.
+func (m *marshaller) value(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	key, ok := valueKey(v)
+	if ok {
+		if name, ok := m.nodes[key]; ok {
+			return name, nil
+		}
+	}
+	name := fmt.Sprintf("n%d", len(m.g.Nodes.Nodes))
+	if ok {
+		m.nodes[key] = name
+	}
+	m.g.AddNode(m.g.Name, name, NewAttrs())
+	if isBasic(v) {
+		m.g.Nodes.Lookup[name].Attrs["label"] = formatValue(v)
+		return name, nil
+	}
+	entries, err := valueEntries(v)
+	if err != nil {
+		return "", err
+	}
+	return name, m.record(name, v.Type().String(), entries)
+}
+
+// record sets the label of the named node to a record of the given entries,
+// and adds edges to the nodes depicting non-basic entries.
+func (m *marshaller) record(name, title string, entries []entry) error {
+	node := m.g.Nodes.Lookup[name]
+	node.Attrs["shape"] = "record"
+	var titles []string
+	edgeAttrs := make([]Attrs, len(entries))
+	for i, e := range entries {
+		if e.opts.label {
+			titles = append(titles, formatValue(e.value))
+		}
+		edgeAttrs[i] = NewAttrs()
+		for _, attr := range e.opts.attrs {
+			attrs, ctx := node.Attrs, ContextNode
+			if spec, ok := LookupAttr(attr[0]); ok && spec.Contexts.Contains(ContextEdge) && !isBasic(e.value) {
+				attrs, ctx = edgeAttrs[i], ContextEdge
+			}
+			if err := ValidateAttr(ctx, attr[0], attr[1]); err != nil {
+				return fmt.Errorf("field %s of %s: %v", e.name, title, err)
+			}
+			attrs[attr[0]] = attr[1]
+		}
+	}
+	if len(titles) > 0 {
+		title = strings.Join(titles, " ")
+	}
+	isRecord := node.isRecord()
+	record := RecordGroup(RecordText("", title))
+	for i, e := range entries {
+		show := !e.opts.omit && !e.opts.label
+		switch {
+		case isBasic(e.value):
+			if show {
+				record.Fields = append(record.Fields, RecordText("", e.name+": "+formatValue(e.value)))
+			}
+		case isNilValue(e.value):
+			if show {
+				record.Fields = append(record.Fields, RecordText("", e.name+": nil"))
+			}
+		default:
+			dst, err := m.value(e.value)
+			if err != nil {
+				return err
+			}
+			edge := &Edge{Src: name, Dst: dst, Dir: true, Attrs: edgeAttrs[i]}
+			if show && isRecord {
+				port := fmt.Sprintf("f%d", i)
+				record.Fields = append(record.Fields, RecordText(port, e.name))
+				edge.SrcPort = ":" + port
+			}
+			m.g.Edges.Add(edge)
+		}
+	}
+	if isRecord {
+		node.Attrs["label"] = record.Label()
+	} else {
+		node.Attrs["label"] = title
+	}
+	return nil
+}
+
+// valueEntries returns the entries of the given struct, slice, array or map.
+func valueEntries(v reflect.Value) ([]entry, error) {
+	var entries []entry
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			opts, err := parseFieldTag(field.Tag.Get("dot"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid dot tag of field %s of %v; %v", field.Name, t, err)
+			}
+			if opts.skip || (opts.omitEmpty && v.Field(i).IsZero()) {
+				continue
+			}
+			name := field.Name
+			if opts.name != "" {
+				name = opts.name
+			}
+			entries = append(entries, entry{name: name, value: v.Field(i), opts: opts})
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			entries = append(entries, entry{name: strconv.Itoa(i), value: v.Index(i)})
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			entries = append(entries, entry{name: formatValue(key), value: v.MapIndex(key)})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].name < entries[j].name
+		})
+	}
+	return entries, nil
+}
+
+// valueKey returns the key identifying the given value, if addressable or a
+// slice or map.
+func valueKey(v reflect.Value) (nodeKey, bool) {
+	switch {
+	case v.CanAddr():
+		return nodeKey{addr: v.UnsafeAddr(), typ: v.Type()}, true
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Map:
+		return nodeKey{addr: v.Pointer(), typ: v.Type(), len: v.Len()}, true
+	}
+	return nodeKey{}, false
+}
+
+// isBasic reports whether the given value is shown as a field value rather
+// than depicted by a node.
+func isBasic(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return isStringer(v)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return false
+	}
+	return true
+}
+
+// isStringer reports whether the given struct implements fmt.Stringer and has
+// no exported fields.
+func isStringer(v reflect.Value) bool {
+	if _, ok := v.Interface().(fmt.Stringer); !ok {
+		if !v.CanAddr() {
+			return false
+		}
+		if _, ok := v.Addr().Interface().(fmt.Stringer); !ok {
+			return false
+		}
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isNilValue reports whether the given value is, or points to, a nil pointer,
+// interface, slice or map.
+func isNilValue(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+	return false
+}
+
+// formatValue returns the textual form of the given basic value.
+func formatValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Type().String()
+	case reflect.Struct:
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		if v.CanAddr() {
+			if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+				return s.String()
+			}
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
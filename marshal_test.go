@@ -0,0 +1,92 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type marshalList struct {
+	Name   string `dot:"label"`
+	Value  int
+	Next   *marshalList `dot:"color=red"`
+	Prev   *marshalList `dot:"omit,style=dashed"`
+	Notes  []string     `dot:"omitempty"`
+	Tags   map[string]int
+	Ptr    *float64
+	Skip   string    `dot:"-"`
+	Due    time.Time `dot:"name=due"`
+	hidden int
+}
+
+type marshalBox struct {
+	Title string `dot:"label,omit,shape=box"`
+	Items [2]*marshalList
+}
+
+func TestMarshal(t *testing.T) {
+	x := 1.5
+	a := &marshalList{Name: "a", Value: 1, Tags: map[string]int{"y": 2, "x": 1}, Ptr: &x, Due: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), hidden: 1}
+	b := &marshalList{Name: "b", Value: 2, Prev: a, Notes: []string{"first|note"}}
+	a.Next = b
+	g, err := Marshal(&marshalBox{Title: "box", Items: [2]*marshalList{a, b}})
+	check(t, err)
+	assert(t, "nodes", len(g.Nodes.Nodes), 6)
+	box := g.Nodes.Lookup["n0"]
+	assert(t, "box shape", box.Attrs["shape"], "box")
+	assert(t, "box label", box.Attrs["label"], `"box"`)
+	items := g.Nodes.Lookup["n1"]
+	assert(t, "array", items.Attrs["label"], `[2]*dot.marshalList|<f0> 0|<f1> 1`)
+	na := g.Nodes.Lookup["n2"]
+	assert(t, "record", na.Attrs["label"], `"a"|Value: 1|<f2> Next|<f4> Tags|Ptr: 1.5|due: 2024-01-02 00:00:00 +0000 UTC`)
+	nb := g.Nodes.Lookup["n3"]
+	assert(t, "cycle", nb.Attrs["label"], `"b"|Value: 2|Next: nil|<f4> Notes|Tags: nil|Ptr: nil|due: 0001-01-01 00:00:00 +0000 UTC`)
+	var edges []string
+	for _, edge := range g.Edges.Edges {
+		s := edgeString(edge)
+		for _, name := range edge.Attrs.SortedNames() {
+			s += " " + name + "=" + edge.Attrs[name]
+		}
+		edges = append(edges, s)
+	}
+	assert(t, "edges", strings.Join(edges, "\n"), strings.Join([]string{
+		"n3->n2 style=dashed",
+		"n3:f4->n4",
+		"n2:f2->n3 color=red",
+		"n2:f4->n5",
+		"n1:f0->n2",
+		"n1:f1->n3",
+		"n0->n1",
+	}, "\n"))
+	assert(t, "map", g.Nodes.Lookup["n5"].Attrs["label"], `map[string]int|"x": 1|"y": 2`)
+	assert(t, "escaped", g.Nodes.Lookup["n4"].Attrs["label"], `[]string|0: "first\|note"`)
+	assert(t, "shared pointer", len(g.Nodes.Lookup["n3"].Preds), 2)
+	assert(t, "lint", len(Lint(g)), 0)
+
+	g, err = Marshal(42)
+	check(t, err)
+	assert(t, "basic", g.Nodes.Nodes[0].Attrs["label"], "42")
+	g, err = Marshal(nil)
+	check(t, err)
+	assert(t, "nil", len(g.Nodes.Nodes), 0)
+
+	type badTag struct {
+		A int `dot:"a,bold"`
+	}
+	if _, err := Marshal(badTag{}); err == nil {
+		t.Errorf("expected error for unknown option")
+	}
+	type badAttr struct {
+		A *int `dot:"color=bleu"`
+	}
+	if _, err := Marshal(badAttr{A: new(int)}); err == nil {
+		t.Errorf("expected error for invalid attribute")
+	}
+	type nilAttr struct {
+		A *int `dot:"color=red"`
+	}
+	g, err = Marshal(nilAttr{})
+	check(t, err)
+	assert(t, "nil field edges", len(g.Edges.Edges), 0)
+	assert(t, "nil field node color", g.Nodes.Nodes[0].Attrs["color"], "")
+}
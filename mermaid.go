@@ -0,0 +1,356 @@
+package dot
+
+// This file defines the conversion of graphs to Mermaid flowcharts, as
+// documented at https://mermaid.js.org/syntax/flowchart.html
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// mermaidDirs maps values of rankdir to Mermaid flowchart directions.
+var mermaidDirs = map[string]string{
+	"TB": "TB",
+	"LR": "LR",
+	"BT": "BT",
+	"RL": "RL",
+}
+
+// mermaidShapes maps node shapes to the delimiters of Mermaid node shapes.
+// Other shapes are depicted by rectangles.
+var mermaidShapes = map[string][2]string{
+	"box":           {"[", "]"},
+	"rect":          {"[", "]"},
+	"rectangle":     {"[", "]"},
+	"square":        {"[", "]"},
+	"ellipse":       {"([", "])"},
+	"oval":          {"([", "])"},
+	"circle":        {"((", "))"},
+	"point":         {"((", "))"},
+	"doublecircle":  {"(((", ")))"},
+	"diamond":       {"{", "}"},
+	"hexagon":       {"{{", "}}"},
+	"parallelogram": {"[/", "/]"},
+	"trapezium":     {"[/", `\]`},
+	"invtrapezium":  {`[\`, "/]"},
+	"cylinder":      {"[(", ")]"},
+	"component":     {"[[", "]]"},
+	"Mrecord":       {"(", ")"},
+	"cds":           {">", "]"},
+}
+
+// mermaidReserved are words which may not be used as Mermaid IDs.
+var mermaidReserved = map[string]bool{
+	"end":       true,
+	"graph":     true,
+	"flowchart": true,
+	"subgraph":  true,
+	"direction": true,
+	"style":     true,
+	"classDef":  true,
+	"class":     true,
+	"click":     true,
+	"call":      true,
+	"href":      true,
+	"linkStyle": true,
+	"default":   true,
+}
+
+// Mermaid returns the graph as a Mermaid flowchart.
+//
+// The direction of the flowchart and of subgraphs is given by rankdir, and
+// nodes are depicted by the Mermaid shapes closest to their shape, e.g.
+// stadiums for ellipses, and rounded rectangles for rounded boxes. Subgraphs
+// are depicted by Mermaid subgraphs titled by their label or name, and nodes of
+// several subgraphs are placed in their innermost cluster. Anonymous subgraphs
+// are not depicted, and edges to them are depicted by links to each of their
+// nodes. Edges are depicted by dotted links if dashed or dotted, thick links if
+// bold, and invisible links if invisible, with their label. Ports are not
+// represented.
+//
+// Names which are not valid Mermaid IDs, e.g. those with characters other
+// than letters, digits and underscores, or reserved words such as "end", are
+// replaced by valid IDs, and labels retain the original names.
+func (g *Graph) Mermaid() string {
+	m := &mermaidWriter{g: g, ids: mermaidIds(g), buf: &strings.Builder{}}
+	dir, ok := mermaidDirs[g.Attrs["rankdir"]]
+	if !ok {
+		dir = "TB"
+	}
+	fmt.Fprintf(m.buf, "flowchart %s\n", dir)
+	m.graph(g.Name, 1)
+	for _, edge := range g.Edges.Edges {
+		m.edge(edge)
+	}
+	return m.buf.String()
+}
+
+// mermaidWriter holds the state of writing a graph as a Mermaid flowchart.
+type mermaidWriter struct {
+	g *Graph
+	// Mermaid IDs of nodes and subgraphs.
+	ids map[string]string
+	buf *strings.Builder
+}
+
+// graph writes the nodes and subgraphs of the named graph or subgraph at the
+// given level of indentation.
+func (m *mermaidWriter) graph(name string, level int) {
+	g := m.g
+	indent := strings.Repeat("    ", level)
+	for _, node := range g.Nodes.Nodes {
+		if m.nodeSubGraph(node.Name) != name {
+			continue
+		}
+		delims, ok := mermaidShapes[node.Attr("shape")]
+		if !ok {
+			delims = mermaidShapes["box"]
+		}
+		if delims == mermaidShapes["box"] && hasStyle(node.Attrs, "rounded") {
+			delims = mermaidShapes["Mrecord"]
+		}
+		fmt.Fprintf(m.buf, "%s%s%s\"%s\"%s\n", indent, m.ids[node.Name], delims[0], m.nodeText(node), delims[1])
+	}
+	for _, name := range m.childSubGraphs(name) {
+		sub := g.SubGraphs.SubGraphs[name]
+		title := mermaidText(name)
+		if _, ok := sub.Attrs["label"]; ok {
+			title = m.text(sub, name)
+		}
+		fmt.Fprintf(m.buf, "%ssubgraph %s [\"%s\"]\n", indent, m.ids[name], title)
+		if dir, ok := mermaidDirs[sub.Attrs["rankdir"]]; ok {
+			fmt.Fprintf(m.buf, "%s    direction %s\n", indent, dir)
+		}
+		m.graph(name, level+1)
+		fmt.Fprintf(m.buf, "%send\n", indent)
+	}
+}
+
+// isAnonymous reports whether the named subgraph is anonymous.
+func (m *mermaidWriter) isAnonymous(name string) bool {
+	return m.g.IsSubGraph(name) && isAnonymous(name)
+}
+
+// depicted returns the innermost depicted graph or subgraph enclosing the
+// named subgraph, or the subgraph itself if not anonymous.
+func (m *mermaidWriter) depicted(name string) string {
+	seen := make(map[string]bool)
+	for m.isAnonymous(name) && !seen[name] {
+		seen[name] = true
+		name = m.g.SubGraphs.SubGraphs[name].Parent
+	}
+	if !m.g.IsSubGraph(name) {
+		return m.g.Name
+	}
+	return name
+}
+
+// nodeSubGraph returns the name of the depicted graph or subgraph in which the
+// named node is placed.
+func (m *mermaidWriter) nodeSubGraph(name string) string {
+	var subs []string
+	for _, parent := range sortedKeys(m.g.Relations.ChildToParents[name]) {
+		if m.g.IsSubGraph(parent) {
+			subs = append(subs, m.depicted(parent))
+		}
+	}
+	return innermostSubGraph(m.g, subs)
+}
+
+// childSubGraphs returns the sorted names of the depicted subgraphs directly
+// contained in the named graph or subgraph, or in its anonymous subgraphs.
+func (m *mermaidWriter) childSubGraphs(name string) []string {
+	var names []string
+	for _, sub := range childSubGraphs(m.g, name) {
+		if m.isAnonymous(sub) {
+			names = append(names, m.childSubGraphs(sub)...)
+		} else {
+			names = append(names, sub)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// edge writes the given edge, with a link to or from each node of anonymous
+// subgraph endpoints.
+func (m *mermaidWriter) edge(edge *Edge) {
+	// Undirected and directed links.
+	links := [2]string{"---", "-->"}
+	switch {
+	case hasStyle(edge.Attrs, "invis"):
+		links = [2]string{"~~~", "~~~"}
+	case hasStyle(edge.Attrs, "dashed") || hasStyle(edge.Attrs, "dotted"):
+		links = [2]string{"-.-", "-.->"}
+	case hasStyle(edge.Attrs, "bold"):
+		links = [2]string{"===", "==>"}
+	}
+	link := links[0]
+	if edge.Dir {
+		link = links[1]
+	}
+	if _, ok := edge.Attrs["label"]; ok && !hasStyle(edge.Attrs, "invis") {
+		link += fmt.Sprintf("|\"%s\"|", m.text(edge, ""))
+	}
+	for _, src := range m.endpoints(edge.Src) {
+		for _, dst := range m.endpoints(edge.Dst) {
+			fmt.Fprintf(m.buf, "    %s %s %s\n", m.ids[src], link, m.ids[dst])
+		}
+	}
+}
+
+// endpoints returns the names of the nodes of the given edge endpoint if an
+// anonymous subgraph, or otherwise the endpoint itself.
+func (m *mermaidWriter) endpoints(name string) []string {
+	if m.isAnonymous(name) {
+		return subGraphNodes(m.g, name)
+	}
+	return []string{name}
+}
+
+// nodeText returns the text of the label of the given node. The text fields of
+// records are separated by vertical bars, and HTML-like labels are reduced to
+// their character data.
+func (m *mermaidWriter) nodeText(node *Node) string {
+	if node.isRecord() && !node.HTMLAttrs["label"] {
+		record, err := node.Record()
+		if err != nil {
+			return mermaidText(node.Name)
+		}
+		var fields []string
+		record.walk(func(field *RecordField) {
+			if !field.IsGroup() && field.Text != "" {
+				fields = append(fields, mermaidText(field.Text))
+			}
+		})
+		return strings.Join(fields, " | ")
+	}
+	return m.text(node, node.Name)
+}
+
+// text returns the text of the label of the given graph, subgraph, node or
+// edge, with lines separated by <br>, or the given fallback if invalid.
+func (m *mermaidWriter) text(elem interface{}, fallback string) string {
+	label, isHTML, err := rawText(elem, "label")
+	if err != nil {
+		return mermaidText(fallback)
+	}
+	if isHTML {
+		parsed, err := ParseHTML(label)
+		if err != nil {
+			return mermaidText(fallback)
+		}
+		var lines []string
+		line := &strings.Builder{}
+		var walk func(nodes []HTMLNode)
+		walk = func(nodes []HTMLNode) {
+			for _, node := range nodes {
+				switch node := node.(type) {
+				case HTMLText:
+					line.WriteString(html.UnescapeString(string(node)))
+				case *HTMLElement:
+					if node.Tag == HTMLBR {
+						lines = append(lines, mermaidText(line.String()))
+						line.Reset()
+					}
+					walk(node.Children)
+				}
+			}
+		}
+		walk(parsed.Nodes)
+		return strings.Join(append(lines, mermaidText(line.String())), "<br>")
+	}
+	text, err := ExpandText(m.g, elem, "label")
+	if err != nil {
+		return mermaidText(fallback)
+	}
+	var lines []string
+	for _, line := range text {
+		lines = append(lines, mermaidText(line.Text))
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// mermaidText escapes the characters of s with special meaning in Mermaid
+// strings as entity codes.
+func mermaidText(s string) string {
+	return strings.NewReplacer(
+		"#", "#35;",
+		`"`, "#quot;",
+		"<", "#lt;",
+		">", "#gt;",
+	).Replace(s)
+}
+
+// hasStyle reports whether the style attribute of the given attributes
+// contains the named style.
+func hasStyle(attrs Attrs, style string) bool {
+	for _, s := range strings.Split(attrs["style"], ",") {
+		if strings.TrimSpace(s) == style {
+			return true
+		}
+	}
+	return false
+}
+
+// mermaidIds returns the Mermaid IDs of the nodes and subgraphs of the graph.
+// Valid names are used as IDs, and other names are replaced by unique IDs
+// derived from them.
+func mermaidIds(g *Graph) map[string]string {
+	var names []string
+	for _, node := range g.Nodes.Nodes {
+		names = append(names, node.Name)
+	}
+	for _, sub := range g.SubGraphs.Sorted() {
+		names = append(names, sub.Name)
+	}
+	ids := make(map[string]string)
+	used := make(map[string]bool)
+	for _, name := range names {
+		if isMermaidId(name) {
+			ids[name] = name
+			used[name] = true
+		}
+	}
+	for _, name := range names {
+		if isMermaidId(name) {
+			continue
+		}
+		base := strings.Map(func(r rune) rune {
+			if isMermaidIdChar(r) {
+				return r
+			}
+			return '_'
+		}, name)
+		if !isMermaidId(base) {
+			base += "_"
+		}
+		id := base
+		for i := 2; used[id]; i++ {
+			id = fmt.Sprintf("%s_%d", base, i)
+		}
+		ids[name] = id
+		used[id] = true
+	}
+	return ids
+}
+
+// isMermaidId reports whether s is a valid Mermaid ID.
+func isMermaidId(s string) bool {
+	if s == "" || mermaidReserved[s] {
+		return false
+	}
+	for _, r := range s {
+		if !isMermaidIdChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMermaidIdChar reports whether r is valid in Mermaid IDs.
+func isMermaidIdChar(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
@@ -0,0 +1,81 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMermaid(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph cluster_api {
+			label="API \"v2\"";
+			rankdir=TB;
+			"auth-service" [shape=box, style="rounded,filled"];
+			db [shape=cylinder];
+		}
+		end [shape=doublecircle];
+		rec [shape=record, label="<f0> a|{b|c}"];
+		html [label=<one<br/>two &amp; three>];
+		"auth-service" -> db [label="reads\nwrites", style=dashed];
+		db -> end [style=bold];
+		end -> rec [style=invis];
+		rec -> html;
+		auth_service;
+	}`))
+	check(t, err)
+	g.AddEdge("auth_service", "cluster_api", true, NewAttrs())
+	want := `flowchart LR
+    end_(((\"end\")))
+    rec[\"a | b | c\"]
+    html([\"one<br>two & three\"])
+    auth_service([\"auth_service\"])
+    subgraph cluster_api [\"API #quot;v2#quot;\"]
+        direction TB
+        auth_service_2(\"auth-service\")
+        db[(\"db\")]
+    end
+    auth_service_2 -.->|\"reads<br>writes\"| db
+    db ==> end_
+    end_ ~~~ rec
+    rec --> html
+    auth_service --> cluster_api
+`
+	want = strings.Replace(want, `\"`, `"`, -1)
+	assert(t, "mermaid", g.Mermaid(), want)
+
+	// Anonymous subgraphs are not depicted, and nodes are placed in their
+	// innermost cluster.
+	g, err = Read([]byte(`digraph {
+		subgraph cluster_a { subgraph cluster_b { y } }
+		{ rank=same; y; z }
+		a -> { b c };
+	}`))
+	check(t, err)
+	want = `flowchart TB
+    z([\"z\"])
+    a([\"a\"])
+    b([\"b\"])
+    c([\"c\"])
+    subgraph cluster_a [\"cluster_a\"]
+        subgraph cluster_b [\"cluster_b\"]
+            y([\"y\"])
+        end
+    end
+    a --> b
+    a --> c
+`
+	want = strings.Replace(want, `\"`, `"`, -1)
+	assert(t, "anonymous subgraphs", g.Mermaid(), want)
+
+	// Only names generated by the parser are anonymous.
+	g, err = Read([]byte(`digraph { subgraph anonymous_users { b } }`))
+	check(t, err)
+	want = `flowchart TB
+    subgraph anonymous_users [\"anonymous_users\"]
+        b([\"b\"])
+    end
+`
+	want = strings.Replace(want, `\"`, `"`, -1)
+	assert(t, "named subgraph", g.Mermaid(), want)
+}
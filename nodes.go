@@ -22,6 +22,7 @@ import (
 type Node struct {
 	Name         string
 	Attrs        Attrs
+	HTMLAttrs    HTMLAttrs
 	Index        int     // index of this node within Graph.Nodes.Nodes of its parent.
 	Preds, Succs []*Node // predecessors and successors
 	dom          domInfo // dominator tree info
@@ -72,6 +73,11 @@ func NewNodes() *Nodes {
 func (this *Nodes) Add(node *Node) {
 	n, ok := this.Lookup[node.Name]
 	if ok {
+		for name := range node.Attrs {
+			if _, ok := n.Attrs[name]; !ok && node.HTMLAttrs[name] {
+				n.HTMLAttrs.set(name, true)
+			}
+		}
 		n.Attrs.Ammend(node.Attrs)
 		return
 	}
@@ -0,0 +1,298 @@
+package dot
+
+// This file defines shortest path algorithms, with arc weights taken from edge
+// attributes.
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// Weight specifies how to derive the weight of an arc from the attributes of
+// its edge.
+type Weight struct {
+	// Attr is the name of the edge attribute holding the weight, e.g.
+	// "weight", "len" or "cost".
+	Attr string
+	// Default is the weight of edges lacking the attribute.
+	Default float64
+}
+
+// Of returns the weight of the given edge.
+func (w Weight) Of(edge *Edge) (float64, error) {
+	f, ok, err := edge.Attrs.Float(w.Attr)
+	if err != nil {
+		return 0, fmt.Errorf("edge %q -> %q: %v", edge.Src, edge.Dst, err)
+	}
+	if !ok {
+		return w.Default, nil
+	}
+	return f, nil
+}
+
+// weightedArc is an arc with its weight.
+type weightedArc struct {
+	Arc
+	weight float64
+}
+
+// weightedArcs returns the weighted outgoing arcs of each node of the graph,
+// indexed by Node.Index.
+func (g *Graph) weightedArcs(w Weight) ([][]weightedArc, error) {
+	out := make([][]weightedArc, len(g.Nodes.Nodes))
+	for _, arc := range g.Arcs() {
+		weight, err := w.Of(arc.Edge)
+		if err != nil {
+			return nil, err
+		}
+		out[arc.Src.Index] = append(out[arc.Src.Index], weightedArc{Arc: arc, weight: weight})
+	}
+	return out, nil
+}
+
+// ShortestPaths is a shortest path tree rooted at a source node.
+type ShortestPaths struct {
+	// Source node of each path.
+	Source *Node
+
+	// Each slice is indexed by Node.Index.
+	dist []float64 // cost of the shortest path from source; +Inf if unreachable
+	prev []*Node   // predecessor on the shortest path from source
+}
+
+// newShortestPaths returns a shortest path tree of the graph with only the
+// source node reached.
+func newShortestPaths(g *Graph, src *Node) *ShortestPaths {
+	n := len(g.Nodes.Nodes)
+	sp := &ShortestPaths{
+		Source: src,
+		dist:   make([]float64, n),
+		prev:   make([]*Node, n),
+	}
+	for i := range sp.dist {
+		sp.dist[i] = math.Inf(1)
+	}
+	sp.dist[src.Index] = 0
+	return sp
+}
+
+// Cost returns the total cost of the shortest path from the source to dst, or
+// +Inf if dst is unreachable.
+func (sp *ShortestPaths) Cost(dst *Node) float64 {
+	return sp.dist[dst.Index]
+}
+
+// PathTo returns the shortest path from the source to dst and its total cost.
+// The path is nil if dst is unreachable.
+func (sp *ShortestPaths) PathTo(dst *Node) ([]*Node, float64) {
+	cost := sp.dist[dst.Index]
+	if math.IsInf(cost, 1) {
+		return nil, cost
+	}
+	var path []*Node
+	for n := dst; n != nil; n = sp.prev[n.Index] {
+		path = append(path, n)
+		if n == sp.Source {
+			break
+		}
+	}
+	reverse(path)
+	return path, cost
+}
+
+// reverse reverses the order of the given nodes in place.
+func reverse(nodes []*Node) {
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+}
+
+// ShortestPath returns the shortest path from src to dst and its total cost,
+// using Dijkstra's algorithm. All weights must be non-negative.
+func (g *Graph) ShortestPath(src, dst *Node, w Weight) ([]*Node, float64, error) {
+	sp, err := g.Dijkstra(src, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	path, cost := sp.PathTo(dst)
+	if path == nil {
+		return nil, 0, fmt.Errorf("no path from %q to %q", src, dst)
+	}
+	return path, cost, nil
+}
+
+// Dijkstra computes the shortest paths from src to all other nodes using
+// Dijkstra's algorithm. All weights must be non-negative.
+func (g *Graph) Dijkstra(src *Node, w Weight) (*ShortestPaths, error) {
+	out, err := g.weightedArcs(w)
+	if err != nil {
+		return nil, err
+	}
+	for _, arcs := range out {
+		for _, arc := range arcs {
+			if arc.weight < 0 {
+				return nil, fmt.Errorf("negative weight %v of edge %q -> %q", arc.weight, arc.Edge.Src, arc.Edge.Dst)
+			}
+		}
+	}
+	sp := newShortestPaths(g, src)
+	done := make([]bool, len(g.Nodes.Nodes))
+	q := &nodeQueue{{node: src, dist: 0}}
+	for q.Len() > 0 {
+		v := heap.Pop(q).(nodeDist).node
+		if done[v.Index] {
+			continue
+		}
+		done[v.Index] = true
+		for _, arc := range out[v.Index] {
+			dist := sp.dist[v.Index] + arc.weight
+			if dist < sp.dist[arc.Dst.Index] {
+				sp.dist[arc.Dst.Index] = dist
+				sp.prev[arc.Dst.Index] = v
+				heap.Push(q, nodeDist{node: arc.Dst, dist: dist})
+			}
+		}
+	}
+	return sp, nil
+}
+
+// nodeDist is a node with its tentative distance from the source.
+type nodeDist struct {
+	node *Node
+	dist float64
+}
+
+// nodeQueue is a min-heap of nodes ordered by distance, implementing
+// heap.Interface.
+type nodeQueue []nodeDist
+
+func (q nodeQueue) Len() int           { return len(q) }
+func (q nodeQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q nodeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue) Push(x interface{}) {
+	*q = append(*q, x.(nodeDist))
+}
+
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+// BellmanFord computes the shortest paths from src to all other nodes using
+// the Bellman-Ford algorithm. Negative weights are permitted, but an error is
+// returned if a negative cycle is reachable from src.
+func (g *Graph) BellmanFord(src *Node, w Weight) (*ShortestPaths, error) {
+	out, err := g.weightedArcs(w)
+	if err != nil {
+		return nil, err
+	}
+	sp := newShortestPaths(g, src)
+	relax := func() bool {
+		changed := false
+		for _, arcs := range out {
+			for _, arc := range arcs {
+				dist := sp.dist[arc.Src.Index] + arc.weight
+				if dist < sp.dist[arc.Dst.Index] {
+					sp.dist[arc.Dst.Index] = dist
+					sp.prev[arc.Dst.Index] = arc.Src
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+	for i := 1; i < len(g.Nodes.Nodes); i++ {
+		if !relax() {
+			return sp, nil
+		}
+	}
+	if relax() {
+		return nil, fmt.Errorf("negative cycle reachable from %q", src)
+	}
+	return sp, nil
+}
+
+// AllShortestPaths holds the shortest paths between all pairs of nodes.
+type AllShortestPaths struct {
+	// Both tables are indexed by the Node.Index of source and destination.
+	dist [][]float64 // cost of the shortest path; +Inf if unreachable
+	next [][]*Node   // successor of the source on the shortest path
+}
+
+// Cost returns the total cost of the shortest path from src to dst, or +Inf
+// if dst is unreachable from src.
+func (ap *AllShortestPaths) Cost(src, dst *Node) float64 {
+	return ap.dist[src.Index][dst.Index]
+}
+
+// Path returns the shortest path from src to dst and its total cost. The path
+// is nil if dst is unreachable from src.
+func (ap *AllShortestPaths) Path(src, dst *Node) ([]*Node, float64) {
+	cost := ap.dist[src.Index][dst.Index]
+	if math.IsInf(cost, 1) {
+		return nil, cost
+	}
+	path := []*Node{src}
+	for n := src; n != dst; {
+		n = ap.next[n.Index][dst.Index]
+		path = append(path, n)
+	}
+	return path, cost
+}
+
+// FloydWarshall computes the shortest paths between all pairs of nodes using
+// the Floyd-Warshall algorithm. Negative weights are permitted, but an error
+// is returned if the graph contains a negative cycle.
+func (g *Graph) FloydWarshall(w Weight) (*AllShortestPaths, error) {
+	out, err := g.weightedArcs(w)
+	if err != nil {
+		return nil, err
+	}
+	n := len(g.Nodes.Nodes)
+	ap := &AllShortestPaths{
+		dist: make([][]float64, n),
+		next: make([][]*Node, n),
+	}
+	for i, node := range g.Nodes.Nodes {
+		ap.dist[i] = make([]float64, n)
+		ap.next[i] = make([]*Node, n)
+		for j := range ap.dist[i] {
+			ap.dist[i][j] = math.Inf(1)
+		}
+		ap.dist[i][i] = 0
+		ap.next[i][i] = node
+	}
+	for _, arcs := range out {
+		for _, arc := range arcs {
+			i, j := arc.Src.Index, arc.Dst.Index
+			if arc.weight < ap.dist[i][j] {
+				ap.dist[i][j] = arc.weight
+				ap.next[i][j] = arc.Dst
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(ap.dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if dist := ap.dist[i][k] + ap.dist[k][j]; dist < ap.dist[i][j] {
+					ap.dist[i][j] = dist
+					ap.next[i][j] = ap.next[i][k]
+				}
+			}
+		}
+	}
+	for i, node := range g.Nodes.Nodes {
+		if ap.dist[i][i] < 0 {
+			return nil, fmt.Errorf("negative cycle through %q", node)
+		}
+	}
+	return ap, nil
+}
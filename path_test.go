@@ -0,0 +1,68 @@
+package dot
+
+import (
+	"math"
+	"testing"
+)
+
+const weightedGraph = `digraph G {
+	a -> b [cost=4];
+	a -> c [cost=1];
+	c -> b [cost=2];
+	b -> d [cost=1];
+	c -> d [cost="5"];
+	e;
+}`
+
+func TestShortestPath(t *testing.T) {
+	g, err := Read([]byte(weightedGraph))
+	check(t, err)
+	w := Weight{Attr: "cost", Default: 1}
+	a, d := g.Nodes.Lookup["a"], g.Nodes.Lookup["d"]
+	path, cost, err := g.ShortestPath(a, d, w)
+	check(t, err)
+	assert(t, "path", nodeNames(path), "[a c b d]")
+	assert(t, "cost", cost, 4.0)
+	if _, _, err := g.ShortestPath(a, g.Nodes.Lookup["e"], w); err == nil {
+		t.Fatalf("expected error for unreachable node")
+	}
+
+	sp, err := g.BellmanFord(a, w)
+	check(t, err)
+	path, cost = sp.PathTo(d)
+	assert(t, "Bellman-Ford path", nodeNames(path), "[a c b d]")
+	assert(t, "Bellman-Ford cost", cost, 4.0)
+
+	ap, err := g.FloydWarshall(w)
+	check(t, err)
+	path, cost = ap.Path(a, d)
+	assert(t, "Floyd-Warshall path", nodeNames(path), "[a c b d]")
+	assert(t, "Floyd-Warshall cost", cost, 4.0)
+	assert(t, "Floyd-Warshall unreachable", math.IsInf(ap.Cost(d, a), 1), true)
+}
+
+func TestShortestPathDefaultWeight(t *testing.T) {
+	g, err := Read([]byte(`graph G { a -- b -- c; a -- c [len=5]; }`))
+	check(t, err)
+	c, a := g.Nodes.Lookup["c"], g.Nodes.Lookup["a"]
+	path, cost, err := g.ShortestPath(c, a, Weight{Attr: "len", Default: 1})
+	check(t, err)
+	assert(t, "path", nodeNames(path), "[c b a]")
+	assert(t, "cost", cost, 2.0)
+}
+
+func TestShortestPathNegativeCycle(t *testing.T) {
+	g, err := Read([]byte(`digraph G { a -> b [weight=1]; b -> a [weight=-2]; }`))
+	check(t, err)
+	w := Weight{Attr: "weight", Default: 1}
+	a := g.Nodes.Lookup["a"]
+	if _, err := g.Dijkstra(a, w); err == nil {
+		t.Fatalf("expected error for negative weight")
+	}
+	if _, err := g.BellmanFord(a, w); err == nil {
+		t.Fatalf("expected error for negative cycle")
+	}
+	if _, err := g.FloydWarshall(w); err == nil {
+		t.Fatalf("expected error for negative cycle")
+	}
+}
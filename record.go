@@ -0,0 +1,293 @@
+package dot
+
+// This file defines the parsing and building of record labels, as documented
+// at https://graphviz.org/doc/info/shapes.html#record
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecordField is a field of a record label; either a text field with an
+// optional port, or a group of subfields laid out in the opposite direction of
+// its parent, as in "<f0> left|{<f1> mid|<f2> right}".
+type RecordField struct {
+	// Port name of a text field; empty if none.
+	Port string
+	// Text of a text field.
+	Text string
+	// Subfields of a group; nil for text fields.
+	Fields []*RecordField
+}
+
+// RecordText returns a new text field with the given port, which may be empty.
+func RecordText(port, text string) *RecordField {
+	return &RecordField{Port: port, Text: text}
+}
+
+// RecordGroup returns a new group of the given fields.
+func RecordGroup(fields ...*RecordField) *RecordField {
+	return &RecordField{Fields: fields}
+}
+
+// IsGroup reports whether the field is a group of subfields.
+func (f *RecordField) IsGroup() bool {
+	return f.Fields != nil
+}
+
+// Label returns the record label of the field. The fields of a top-level
+// group are not enclosed in braces.
+func (f *RecordField) Label() string {
+	if !f.IsGroup() {
+		return f.String()
+	}
+	var fields []string
+	for _, field := range f.Fields {
+		fields = append(fields, field.String())
+	}
+	return strings.Join(fields, "|")
+}
+
+// String returns the record label of the field, with groups enclosed in
+// braces.
+func (f *RecordField) String() string {
+	if f.IsGroup() {
+		return "{" + f.Label() + "}"
+	}
+	text := escapeRecord(f.Text)
+	if f.Port == "" {
+		return text
+	}
+	if text == "" {
+		return "<" + escapeRecord(f.Port) + ">"
+	}
+	return "<" + escapeRecord(f.Port) + "> " + text
+}
+
+// Ports returns the port names of the field and its subfields, in order.
+func (f *RecordField) Ports() []string {
+	var ports []string
+	f.walk(func(field *RecordField) {
+		if field.Port != "" {
+			ports = append(ports, field.Port)
+		}
+	})
+	return ports
+}
+
+// Lookup returns the field with the given port name, or nil if not found.
+func (f *RecordField) Lookup(port string) *RecordField {
+	var found *RecordField
+	f.walk(func(field *RecordField) {
+		if found == nil && field.Port == port {
+			found = field
+		}
+	})
+	return found
+}
+
+// walk calls visit for the field and its subfields, in preorder.
+func (f *RecordField) walk(visit func(field *RecordField)) {
+	visit(f)
+	for _, field := range f.Fields {
+		field.walk(visit)
+	}
+}
+
+// escapeRecord escapes the characters of s with special meaning in record
+// labels, and leading and trailing spaces, which are otherwise ignored.
+func escapeRecord(s string) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case strings.IndexByte("{}|<>", c) != -1:
+			buf.WriteByte('\\')
+		case c == ' ' && (strings.TrimLeft(s[:i], " ") == "" || strings.TrimRight(s[i:], " ") == ""):
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String()
+}
+
+// ParseRecord parses the given record label into a group of fields. Escape
+// sequences of characters with special meaning in record labels, e.g. `\|`,
+// are replaced by the characters, and other escape sequences, e.g. `\n`, are
+// retained.
+func ParseRecord(label string) (*RecordField, error) {
+	p := &recordParser{s: label}
+	fields, err := p.fields(false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record label %q; %v", label, err)
+	}
+	return RecordGroup(fields...), nil
+}
+
+// escapedSpace temporarily replaces escaped spaces during parsing, to retain
+// them when trimming unescaped spaces.
+const escapedSpace = '\x00'
+
+// recordParser holds the state of parsing a record label.
+type recordParser struct {
+	s   string
+	pos int
+}
+
+// fields parses a list of fields separated by vertical bars, which is
+// terminated by a closing brace if nested.
+func (p *recordParser) fields(nested bool) ([]*RecordField, error) {
+	var fields []*RecordField
+	for {
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		if p.pos == len(p.s) {
+			if nested {
+				return nil, fmt.Errorf("missing '}'")
+			}
+			return fields, nil
+		}
+		switch p.s[p.pos] {
+		case '|':
+			p.pos++
+		case '}':
+			if !nested {
+				return nil, fmt.Errorf("unexpected '}' at offset %d", p.pos)
+			}
+			p.pos++
+			return fields, nil
+		}
+	}
+}
+
+// field parses a text field or a group of fields.
+func (p *recordParser) field() (*RecordField, error) {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '{' {
+		p.pos++
+		fields, err := p.fields(true)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] != '|' && p.s[p.pos] != '}' {
+			return nil, fmt.Errorf("unexpected %q after group at offset %d", p.s[p.pos], p.pos)
+		}
+		return RecordGroup(fields...), nil
+	}
+	field := &RecordField{}
+	hasPort := false
+	text := &strings.Builder{}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch c {
+		case '|', '}':
+			field.Text = trimRecord(text.String())
+			return field, nil
+		case '{', '>':
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, p.pos)
+		case '<':
+			if hasPort {
+				return nil, fmt.Errorf("multiple ports in field at offset %d", p.pos)
+			}
+			hasPort = true
+			p.pos++
+			port, err := p.port()
+			if err != nil {
+				return nil, err
+			}
+			field.Port = port
+			continue
+		case '\\':
+			p.escape(text)
+			continue
+		}
+		text.WriteByte(c)
+		p.pos++
+	}
+	field.Text = trimRecord(text.String())
+	return field, nil
+}
+
+// port parses a port name terminated by '>'.
+func (p *recordParser) port() (string, error) {
+	port := &strings.Builder{}
+	for p.pos < len(p.s) {
+		switch c := p.s[p.pos]; c {
+		case '>':
+			p.pos++
+			return trimRecord(port.String()), nil
+		case '\\':
+			p.escape(port)
+		default:
+			port.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("missing '>'")
+}
+
+// escape parses an escape sequence, writing the escaped character to buf if
+// special in record labels, and otherwise the escape sequence.
+func (p *recordParser) escape(buf *strings.Builder) {
+	if p.pos+1 == len(p.s) {
+		buf.WriteByte('\\')
+		p.pos++
+		return
+	}
+	switch c := p.s[p.pos+1]; c {
+	case '{', '}', '|', '<', '>':
+		buf.WriteByte(c)
+	case ' ':
+		buf.WriteByte(escapedSpace)
+	default:
+		buf.WriteString(p.s[p.pos : p.pos+2])
+	}
+	p.pos += 2
+}
+
+// skipSpace skips unescaped whitespace.
+func (p *recordParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) != -1 {
+		p.pos++
+	}
+}
+
+// trimRecord trims unescaped whitespace from s and restores escaped spaces.
+func trimRecord(s string) string {
+	s = strings.TrimSpace(s)
+	return strings.Replace(s, string(escapedSpace), " ", -1)
+}
+
+// isRecord reports whether the node has a record shape.
+func (n *Node) isRecord() bool {
+	shape := n.Attr("shape")
+	return shape == "record" || shape == "Mrecord"
+}
+
+// Record returns the parsed record label of the node. The label of a record
+// node without a label is its name.
+func (n *Node) Record() (*RecordField, error) {
+	if !n.isRecord() {
+		return nil, fmt.Errorf("node %q does not have a record shape", n.Name)
+	}
+	label := n.Attr("label")
+	if label == "" || label == `\N` {
+		return RecordGroup(RecordText("", n.Name)), nil
+	}
+	return ParseRecord(label)
+}
+
+// SetRecord sets the label of the node to the given record, and its shape to
+// record unless already a record shape.
+func (n *Node) SetRecord(record *RecordField) error {
+	if !n.isRecord() {
+		if err := n.SetShape("record"); err != nil {
+			return err
+		}
+	}
+	return n.SetLabel(record.Label())
+}
@@ -0,0 +1,83 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRecord(t *testing.T) {
+	r, err := ParseRecord(`<f0> left|{<f1> mid\ dle|<f2> right}| \{x\}\l`)
+	check(t, err)
+	assert(t, "fields", len(r.Fields), 3)
+	assert(t, "port", r.Fields[0].Port, "f0")
+	assert(t, "text", r.Fields[0].Text, "left")
+	assert(t, "group", r.Fields[1].IsGroup(), true)
+	assert(t, "escaped space", r.Fields[1].Fields[0].Text, "mid dle")
+	assert(t, "escaped braces", r.Fields[2].Text, `{x}\l`)
+	assert(t, "ports", strings.Join(r.Ports(), ","), "f0,f1,f2")
+	assert(t, "lookup", r.Lookup("f2").Text, "right")
+	assert(t, "label", r.Label(), `<f0> left|{<f1> mid dle|<f2> right}|\{x\}\l`)
+
+	r, err = ParseRecord(`<f0> 0xf7fc4380| <f1> | <f2> |-1`)
+	check(t, err)
+	assert(t, "empty text", r.Fields[1].Text, "")
+	assert(t, "label", r.Label(), `<f0> 0xf7fc4380|<f1>|<f2>|-1`)
+
+	for _, label := range []string{"{a|b", "a}|b", "<f0 a", "<a><b> c", "{a} b", "a>b"} {
+		if _, err := ParseRecord(label); err == nil {
+			t.Errorf("%q: expected error", label)
+		}
+	}
+}
+
+func TestBuildRecord(t *testing.T) {
+	r := RecordGroup(
+		RecordText("in", "input"),
+		RecordGroup(RecordText("", "a|b"), RecordText("x", " padded ")),
+		RecordText("out", ""),
+	)
+	label := r.Label()
+	assert(t, "label", label, `<in> input|{a\|b|<x> \ padded\ }|<out>`)
+	parsed, err := ParseRecord(label)
+	check(t, err)
+	assert(t, "round trip", parsed.Label(), label)
+	assert(t, "round trip text", parsed.Fields[1].Fields[1].Text, " padded ")
+
+	g, err := Read([]byte(`digraph { a; }`))
+	check(t, err)
+	a := g.Nodes.Lookup["a"]
+	_, err = a.Record()
+	if err == nil {
+		t.Errorf("expected error for node without record shape")
+	}
+	check(t, a.SetRecord(r))
+	assert(t, "shape", a.Attrs["shape"], "record")
+	got, err := a.Record()
+	check(t, err)
+	assert(t, "record", got.Label(), label)
+}
+
+func TestCheckPorts(t *testing.T) {
+	g, err := ParseFile("testdata/datastruct.gv.txt")
+	check(t, err)
+	assert(t, "datastruct", len(CheckPorts(g)), 0)
+
+	issues, err := LintSource([]byte(`digraph {
+	node [shape=record];
+	a [label="<f0> a|<f1> b"];
+	b;
+	a:f0 -> b:n;
+	a:f2 -> b;
+	a:f1:s -> b:x;
+}`))
+	check(t, err)
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`6:4: edge a:f2->b: port "f2" not declared by record label of node a`,
+		`7:14: edge a:f1:s->b:x: port "x" not declared by record label of node b`,
+	}
+	assert(t, "issues", strings.Join(got, "\n"), strings.Join(want, "\n"))
+}
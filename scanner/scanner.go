@@ -333,6 +333,10 @@ func (S *Scanner) scanNumber() token.Type {
 func (S *Scanner) scanHTML() token.Type {
 	count := 1
 	for count > 0 {
+		if S.ch == -1 {
+			// Unterminated HTML string.
+			return token.ILLEGAL
+		}
 		if S.ch == '<' {
 			count += 1
 		}
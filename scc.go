@@ -0,0 +1,75 @@
+package dot
+
+// This file defines the strongly connected components of graphs.
+
+// StronglyConnectedComponents returns the strongly connected components of
+// the graph, using Tarjan's algorithm. Components are returned in reverse
+// topological order; i.e. no arc leads from a component to a later one.
+func (g *Graph) StronglyConnectedComponents() [][]*Node {
+	n := len(g.Nodes.Nodes)
+	s := &sccState{
+		out:     g.outArcs(),
+		index:   make([]int, n),
+		lowlink: make([]int, n),
+		onStack: make([]bool, n),
+	}
+	for i := range s.index {
+		s.index[i] = -1
+	}
+	for _, node := range g.Nodes.Nodes {
+		if s.index[node.Index] == -1 {
+			s.visit(node)
+		}
+	}
+	return s.comps
+}
+
+// sccState holds the working state for Tarjan's strongly connected components
+// algorithm.
+type sccState struct {
+	out   [][]Arc
+	count int
+	stack []*Node
+	comps [][]*Node
+
+	// Each slice is indexed by Node.Index.
+	index   []int // visitation number; -1 if not yet visited
+	lowlink []int // least visitation number reachable
+	onStack []bool
+}
+
+// visit implements the recursive part of Tarjan's algorithm.
+func (s *sccState) visit(v *Node) {
+	s.index[v.Index] = s.count
+	s.lowlink[v.Index] = s.count
+	s.count++
+	s.stack = append(s.stack, v)
+	s.onStack[v.Index] = true
+	for _, arc := range s.out[v.Index] {
+		w := arc.Dst
+		if s.index[w.Index] == -1 {
+			s.visit(w)
+			if s.lowlink[w.Index] < s.lowlink[v.Index] {
+				s.lowlink[v.Index] = s.lowlink[w.Index]
+			}
+		} else if s.onStack[w.Index] && s.index[w.Index] < s.lowlink[v.Index] {
+			s.lowlink[v.Index] = s.index[w.Index]
+		}
+	}
+	if s.lowlink[v.Index] != s.index[v.Index] {
+		return
+	}
+	// v is the root of a strongly connected component; pop it off the stack.
+	var comp []*Node
+	for {
+		w := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		s.onStack[w.Index] = false
+		comp = append(comp, w)
+		if w == v {
+			break
+		}
+	}
+	reverse(comp)
+	s.comps = append(s.comps, comp)
+}
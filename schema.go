@@ -0,0 +1,778 @@
+package dot
+
+// This file defines the schema of Graphviz attributes, as documented at
+// https://graphviz.org/doc/info/attrs.html
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Context specifies the kinds of graph elements an attribute applies to.
+type Context uint8
+
+// Attribute contexts.
+const (
+	// ContextGraph is the root graph (G).
+	ContextGraph Context = 1 << iota
+	// ContextSubGraph is a subgraph which is not a cluster (S).
+	ContextSubGraph
+	// ContextCluster is a cluster subgraph (C).
+	ContextCluster
+	// ContextNode is a node (N).
+	ContextNode
+	// ContextEdge is an edge (E).
+	ContextEdge
+)
+
+// contextLetters maps from contexts to their letters in the Graphviz
+// documentation.
+var contextLetters = []struct {
+	ctx    Context
+	letter byte
+}{
+	{ContextGraph, 'G'},
+	{ContextSubGraph, 'S'},
+	{ContextCluster, 'C'},
+	{ContextNode, 'N'},
+	{ContextEdge, 'E'},
+}
+
+func (ctx Context) String() string {
+	s := ""
+	for _, c := range contextLetters {
+		if ctx&c.ctx != 0 {
+			s += string(c.letter)
+		}
+	}
+	return s
+}
+
+// Contains reports whether ctx contains each context of other.
+func (ctx Context) Contains(other Context) bool {
+	return ctx&other == other
+}
+
+// SubGraphContext returns the context of the named subgraph; i.e. cluster if
+// the name starts with "cluster".
+func SubGraphContext(name string) Context {
+	if strings.HasPrefix(name, "cluster") {
+		return ContextCluster
+	}
+	return ContextSubGraph
+}
+
+// ValueType is the type of an attribute value.
+type ValueType int
+
+// Value types.
+const (
+	TypeString ValueType = iota
+	TypeEscString
+	TypeLblString
+	TypeDouble
+	TypeInt
+	TypeBool
+	TypePoint
+	TypePointList
+	TypeRect
+	TypeAddDouble
+	TypeAddPoint
+	TypeDoubleList
+	TypeColor
+	TypeColorList
+	TypeArrowType
+	TypeClusterMode
+	TypeDirType
+	TypeOutputMode
+	TypePackMode
+	TypePageDir
+	TypeQuadType
+	TypeRankType
+	TypeRankDir
+	TypeShape
+	TypeSmoothType
+	TypeSplineType
+	TypeStartType
+	TypeStyle
+	TypeLayerList
+	TypeLayerRange
+	TypePortPos
+	TypeViewPort
+)
+
+// typeNames maps from value types to their names in the Graphviz
+// documentation.
+var typeNames = map[ValueType]string{
+	TypeString:      "string",
+	TypeEscString:   "escString",
+	TypeLblString:   "lblString",
+	TypeDouble:      "double",
+	TypeInt:         "int",
+	TypeBool:        "bool",
+	TypePoint:       "point",
+	TypePointList:   "pointList",
+	TypeRect:        "rect",
+	TypeAddDouble:   "addDouble",
+	TypeAddPoint:    "addPoint",
+	TypeDoubleList:  "doubleList",
+	TypeColor:       "color",
+	TypeColorList:   "colorList",
+	TypeArrowType:   "arrowType",
+	TypeClusterMode: "clusterMode",
+	TypeDirType:     "dirType",
+	TypeOutputMode:  "outputMode",
+	TypePackMode:    "packMode",
+	TypePageDir:     "pagedir",
+	TypeQuadType:    "quadType",
+	TypeRankType:    "rankType",
+	TypeRankDir:     "rankdir",
+	TypeShape:       "shape",
+	TypeSmoothType:  "smoothType",
+	TypeSplineType:  "splineType",
+	TypeStartType:   "startType",
+	TypeStyle:       "style",
+	TypeLayerList:   "layerList",
+	TypeLayerRange:  "layerRange",
+	TypePortPos:     "portPos",
+	TypeViewPort:    "viewPort",
+}
+
+func (t ValueType) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ValueType(%d)", int(t))
+}
+
+// Enumerated values of value types.
+var (
+	clusterModes = []string{"local", "global", "none"}
+	dirTypes     = []string{"forward", "back", "both", "none"}
+	outputModes  = []string{"breadthfirst", "nodesfirst", "edgesfirst"}
+	pageDirs     = []string{"BL", "BR", "TL", "TR", "RB", "RT", "LB", "LT"}
+	quadTypes    = []string{"normal", "fast", "none"}
+	rankTypes    = []string{"same", "min", "source", "max", "sink"}
+	rankDirs     = []string{"TB", "LR", "BT", "RL"}
+	smoothTypes  = []string{"none", "avg_dist", "graph_dist", "power_dist", "rng", "spring", "triangle"}
+	shapes       = []string{
+		"box", "polygon", "ellipse", "oval", "circle", "point", "egg",
+		"triangle", "plaintext", "plain", "diamond", "trapezium",
+		"parallelogram", "house", "pentagon", "hexagon", "septagon", "octagon",
+		"doublecircle", "doubleoctagon", "tripleoctagon", "invtriangle",
+		"invtrapezium", "invhouse", "Mdiamond", "Msquare", "Mcircle", "rect",
+		"rectangle", "square", "star", "none", "underline", "cylinder", "note",
+		"tab", "folder", "box3d", "component", "promoter", "cds", "terminator",
+		"utr", "primersite", "restrictionsite", "fivepoverhang",
+		"threepoverhang", "noverhang", "assembly", "signature", "insulator",
+		"ribosite", "rnastab", "proteasesite", "proteinstab", "rpromoter",
+		"rarrow", "larrow", "lpromoter", "record", "Mrecord",
+	}
+	arrowShapes = []string{"box", "crow", "curve", "icurve", "diamond", "dot", "inv", "none", "normal", "tee", "vee"}
+	// Deprecated arrow names which are still accepted by Graphviz.
+	arrowAliases = []string{"ediamond", "open", "halfopen", "empty", "invempty"}
+	// Styles by context.
+	nodeStyles    = []string{"dashed", "dotted", "solid", "invis", "bold", "filled", "striped", "wedged", "diagonals", "rounded", "radial"}
+	edgeStyles    = []string{"dashed", "dotted", "solid", "invis", "bold", "tapered"}
+	clusterStyles = []string{"dashed", "dotted", "solid", "invis", "bold", "filled", "striped", "rounded", "radial"}
+	compassPoints = []string{"n", "ne", "e", "se", "s", "sw", "w", "nw", "c", "_"}
+)
+
+// Value patterns.
+var (
+	floatPat     = `[-+]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][-+]?[0-9]+)?`
+	pointRegexp  = regexp.MustCompile(`^` + floatPat + `,` + floatPat + `(?:,` + floatPat + `)?!?$`)
+	rectRegexp   = regexp.MustCompile(`^` + floatPat + `,` + floatPat + `,` + floatPat + `,` + floatPat + `$`)
+	packRegexp   = regexp.MustCompile(`^(?:node|clust|graph|array(?:_[ctblru]+)?[0-9]*)$`)
+	startRegexp  = regexp.MustCompile(`^(?:regular|self|random)?[0-9]*$`)
+	styleRegexp  = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*(?:\(([^()]*)\))?\s*$`)
+	splineRegexp = regexp.MustCompile(`^[es],` + floatPat + `,` + floatPat + `$`)
+)
+
+// contains reports whether the list contains s.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// enumError returns an error reporting that value is not one of the valid
+// values.
+func enumError(t ValueType, value string, valid []string) error {
+	return fmt.Errorf("invalid %v %q; expected one of %s", t, value, strings.Join(valid, ", "))
+}
+
+// Validate reports an error if the given value, without surrounding quotes, is
+// not a valid value of the type.
+func (t ValueType) Validate(value string) error {
+	switch t {
+	case TypeString, TypeEscString, TypeLblString, TypeLayerList, TypeLayerRange:
+		return nil
+	case TypeDouble:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("invalid double %q", value)
+		}
+	case TypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid int %q", value)
+		}
+	case TypeBool:
+		if _, err := parseBool(value); err != nil {
+			return err
+		}
+	case TypePoint:
+		if !pointRegexp.MatchString(value) {
+			return fmt.Errorf("invalid point %q", value)
+		}
+	case TypePointList:
+		for _, p := range strings.Fields(value) {
+			if !pointRegexp.MatchString(p) {
+				return fmt.Errorf("invalid point %q in point list", p)
+			}
+		}
+	case TypeRect:
+		if !rectRegexp.MatchString(value) {
+			return fmt.Errorf("invalid rect %q", value)
+		}
+	case TypeAddDouble:
+		return TypeDouble.Validate(strings.TrimPrefix(value, "+"))
+	case TypeAddPoint:
+		return TypePoint.Validate(strings.TrimPrefix(value, "+"))
+	case TypeDoubleList:
+		for _, d := range strings.Split(value, ":") {
+			if err := TypeDouble.Validate(d); err != nil {
+				return fmt.Errorf("invalid double list %q", value)
+			}
+		}
+	case TypeColor:
+		if !validColor(value) {
+			return fmt.Errorf("invalid color %q", value)
+		}
+	case TypeColorList:
+		for _, c := range strings.Split(value, ":") {
+			if i := strings.Index(c, ";"); i != -1 {
+				if _, err := strconv.ParseFloat(c[i+1:], 64); err != nil {
+					return fmt.Errorf("invalid weight of color %q", c)
+				}
+				c = c[:i]
+			}
+			if !validColor(c) {
+				return fmt.Errorf("invalid color %q in color list", c)
+			}
+		}
+	case TypeArrowType:
+		if !validArrowType(value) {
+			return fmt.Errorf("invalid arrowType %q", value)
+		}
+	case TypeClusterMode:
+		if !contains(clusterModes, value) {
+			return enumError(t, value, clusterModes)
+		}
+	case TypeDirType:
+		if !contains(dirTypes, value) {
+			return enumError(t, value, dirTypes)
+		}
+	case TypeOutputMode:
+		if !contains(outputModes, value) {
+			return enumError(t, value, outputModes)
+		}
+	case TypePackMode:
+		if !packRegexp.MatchString(value) {
+			return fmt.Errorf("invalid packMode %q", value)
+		}
+	case TypePageDir:
+		if !contains(pageDirs, value) {
+			return enumError(t, value, pageDirs)
+		}
+	case TypeQuadType:
+		if !contains(quadTypes, value) {
+			return enumError(t, value, quadTypes)
+		}
+	case TypeRankType:
+		if !contains(rankTypes, value) {
+			return enumError(t, value, rankTypes)
+		}
+	case TypeRankDir:
+		if !contains(rankDirs, value) {
+			return enumError(t, value, rankDirs)
+		}
+	case TypeShape:
+		// Shapes are case-insensitive, except for the M-prefixed ones.
+		for _, shape := range shapes {
+			if value == shape || strings.ToLower(value) == strings.ToLower(shape) && shape[0] != 'M' {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid shape %q", value)
+	case TypeSmoothType:
+		if !contains(smoothTypes, value) {
+			return enumError(t, value, smoothTypes)
+		}
+	case TypeSplineType:
+		for _, spline := range strings.Split(value, ";") {
+			for _, p := range strings.Fields(spline) {
+				if !pointRegexp.MatchString(p) && !splineRegexp.MatchString(p) {
+					return fmt.Errorf("invalid splineType %q", value)
+				}
+			}
+		}
+	case TypeStartType:
+		if !startRegexp.MatchString(value) {
+			return fmt.Errorf("invalid startType %q", value)
+		}
+	case TypeStyle:
+		_, err := parseStyle(value)
+		return err
+	case TypePortPos:
+		parts := strings.Split(value, ":")
+		if len(parts) > 2 || len(parts) == 2 && !contains(compassPoints, parts[1]) {
+			return fmt.Errorf("invalid portPos %q", value)
+		}
+	case TypeViewPort:
+		parts := strings.Split(value, ",")
+		if len(parts) != 4 && len(parts) != 5 {
+			return fmt.Errorf("invalid viewPort %q", value)
+		}
+		for i, part := range parts {
+			// The fourth field of W,H,Z,N is the name of a node.
+			if len(parts) == 4 && i == 3 {
+				continue
+			}
+			if _, err := strconv.ParseFloat(part, 64); err != nil {
+				return fmt.Errorf("invalid viewPort %q", value)
+			}
+		}
+	}
+	return nil
+}
+
+// parseBool parses a Graphviz boolean; i.e. true, false, yes, no (case
+// insensitive) or an integer, where non-zero is true.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes":
+		return true, nil
+	case "false", "no":
+		return false, nil
+	}
+	if i, err := strconv.Atoi(value); err == nil {
+		return i != 0, nil
+	}
+	return false, fmt.Errorf("invalid bool %q", value)
+}
+
+// validColor reports whether value is a valid colour. As the colour scheme of
+// the element is not known, colour names are accepted if present in the x11 or
+// svg scheme, and numbers are accepted as colours of brewer schemes.
+func validColor(value string) bool {
+	if _, err := ParseColor(value, ""); err == nil {
+		return true
+	}
+	if _, err := ParseColor(value, "svg"); err == nil {
+		return true
+	}
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+// validArrowType reports whether value is a valid arrow type; i.e. one to four
+// arrow shapes, each with optional modifiers, or a deprecated arrow name.
+func validArrowType(value string) bool {
+	if contains(arrowAliases, value) {
+		return true
+	}
+	n := 0
+	for s := value; len(s) > 0; n++ {
+		s = strings.TrimPrefix(s, "o")
+		if strings.HasPrefix(s, "l") || strings.HasPrefix(s, "r") {
+			// Only strip the side modifier if followed by a shape.
+			for _, shape := range arrowShapes {
+				if strings.HasPrefix(s[1:], shape) {
+					s = s[1:]
+					break
+				}
+			}
+		}
+		found := false
+		for _, shape := range arrowShapes {
+			if strings.HasPrefix(s, shape) {
+				s = s[len(shape):]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return n >= 1 && n <= 4
+}
+
+// StyleItem is an item of a style attribute, e.g. "setlinewidth(2)".
+type StyleItem struct {
+	Name string
+	Args []string
+}
+
+// parseStyle parses a comma-separated list of style items.
+func parseStyle(value string) ([]StyleItem, error) {
+	var items []StyleItem
+	// Split on commas outside of parentheses.
+	depth, start := 0, 0
+	var parts []string
+	for i, c := range value {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, value[start:])
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		m := styleRegexp.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("invalid style %q", value)
+		}
+		item := StyleItem{Name: m[1]}
+		if m[2] != "" {
+			for _, arg := range strings.Split(m[2], ",") {
+				item.Args = append(item.Args, strings.TrimSpace(arg))
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// AttrSpec specifies a Graphviz attribute.
+type AttrSpec struct {
+	// Name of the attribute.
+	Name string
+	// Contexts the attribute applies to.
+	Contexts Context
+	// Valid value types of the attribute; a value is valid if it is valid for
+	// any of the types.
+	Types []ValueType
+	// Default value of the attribute; empty if none. The default label of
+	// nodes is their name, \N, and other elements have no default label.
+	Default string
+}
+
+// Validate reports an error if the given value, without surrounding quotes, is
+// not a valid value of the attribute.
+func (spec *AttrSpec) Validate(value string) error {
+	var err error
+	for _, t := range spec.Types {
+		if err = t.Validate(value); err == nil {
+			return nil
+		}
+	}
+	if len(spec.Types) > 1 {
+		var names []string
+		for _, t := range spec.Types {
+			names = append(names, t.String())
+		}
+		return fmt.Errorf("invalid value %q of attribute %q; expected %s", value, spec.Name, strings.Join(names, " or "))
+	}
+	return fmt.Errorf("invalid value of attribute %q; %v", spec.Name, err)
+}
+
+// Short names of contexts and value types used in the attribute table.
+const (
+	cG = ContextGraph
+	cS = ContextSubGraph
+	cC = ContextCluster
+	cN = ContextNode
+	cE = ContextEdge
+)
+
+// types returns the given value types as a slice.
+func types(ts ...ValueType) []ValueType {
+	return ts
+}
+
+// attrSpecs contains the specification of each Graphviz attribute.
+var attrSpecs = []*AttrSpec{
+	{"_background", cG, types(TypeString), ""},
+	{"area", cN | cC, types(TypeDouble), "1.0"},
+	{"arrowhead", cE, types(TypeArrowType), "normal"},
+	{"arrowsize", cE, types(TypeDouble), "1.0"},
+	{"arrowtail", cE, types(TypeArrowType), "normal"},
+	{"bb", cG | cC, types(TypeRect), ""},
+	{"beautify", cG, types(TypeBool), "false"},
+	{"bgcolor", cG | cC, types(TypeColor, TypeColorList), ""},
+	{"center", cG, types(TypeBool), "false"},
+	{"charset", cG, types(TypeString), "UTF-8"},
+	{"class", cG | cC | cN | cE, types(TypeString), ""},
+	{"cluster", cC, types(TypeBool), "false"},
+	{"clusterrank", cG, types(TypeClusterMode), "local"},
+	{"color", cC | cN | cE, types(TypeColor, TypeColorList), "black"},
+	{"colorscheme", cG | cC | cN | cE, types(TypeString), ""},
+	{"comment", cG | cN | cE, types(TypeString), ""},
+	{"compound", cG, types(TypeBool), "false"},
+	{"concentrate", cG, types(TypeBool), "false"},
+	{"constraint", cE, types(TypeBool), "true"},
+	{"Damping", cG, types(TypeDouble), "0.99"},
+	{"decorate", cE, types(TypeBool), "false"},
+	{"defaultdist", cG, types(TypeDouble), ""},
+	{"dim", cG, types(TypeInt), "2"},
+	{"dimen", cG, types(TypeInt), "2"},
+	{"dir", cE, types(TypeDirType), "forward"},
+	{"diredgeconstraints", cG, types(TypeString, TypeBool), "false"},
+	{"distortion", cN, types(TypeDouble), "0.0"},
+	{"dpi", cG, types(TypeDouble), "96.0"},
+	{"edgehref", cE, types(TypeEscString), ""},
+	{"edgetarget", cE, types(TypeEscString), ""},
+	{"edgetooltip", cE, types(TypeEscString), ""},
+	{"edgeURL", cE, types(TypeEscString), ""},
+	{"epsilon", cG, types(TypeDouble), ""},
+	{"esep", cG, types(TypeAddDouble, TypeAddPoint), "+3"},
+	{"fillcolor", cC | cN | cE, types(TypeColor, TypeColorList), ""},
+	{"fixedsize", cN, types(TypeBool, TypeString), "false"},
+	{"fontcolor", cG | cC | cN | cE, types(TypeColor), "black"},
+	{"fontname", cG | cC | cN | cE, types(TypeString), "Times-Roman"},
+	{"fontnames", cG, types(TypeString), ""},
+	{"fontpath", cG, types(TypeString), ""},
+	{"fontsize", cG | cC | cN | cE, types(TypeDouble), "14.0"},
+	{"forcelabels", cG, types(TypeBool), "true"},
+	{"gradientangle", cG | cC | cN, types(TypeInt), ""},
+	{"group", cN, types(TypeString), ""},
+	{"head_lp", cE, types(TypePoint), ""},
+	{"headclip", cE, types(TypeBool), "true"},
+	{"headhref", cE, types(TypeEscString), ""},
+	{"headlabel", cE, types(TypeLblString), ""},
+	{"headport", cE, types(TypePortPos), "center"},
+	{"headtarget", cE, types(TypeEscString), ""},
+	{"headtooltip", cE, types(TypeEscString), ""},
+	{"headURL", cE, types(TypeEscString), ""},
+	{"height", cN, types(TypeDouble), "0.5"},
+	{"href", cG | cC | cN | cE, types(TypeEscString), ""},
+	{"id", cG | cC | cN | cE, types(TypeEscString), ""},
+	{"image", cN, types(TypeString), ""},
+	{"imagepath", cG, types(TypeString), ""},
+	{"imagepos", cN, types(TypeString), "mc"},
+	{"imagescale", cN, types(TypeBool, TypeString), "false"},
+	{"inputscale", cG, types(TypeDouble), ""},
+	{"K", cG | cC, types(TypeDouble), "0.3"},
+	{"label", cG | cC | cN | cE, types(TypeLblString), `\N`},
+	{"label_scheme", cG, types(TypeInt), "0"},
+	{"labelangle", cE, types(TypeDouble), "-25.0"},
+	{"labeldistance", cE, types(TypeDouble), "1.0"},
+	{"labelfloat", cE, types(TypeBool), "false"},
+	{"labelfontcolor", cE, types(TypeColor), "black"},
+	{"labelfontname", cE, types(TypeString), "Times-Roman"},
+	{"labelfontsize", cE, types(TypeDouble), "14.0"},
+	{"labelhref", cE, types(TypeEscString), ""},
+	{"labeljust", cG | cC, types(TypeString), "c"},
+	{"labelloc", cG | cC | cN, types(TypeString), ""},
+	{"labeltarget", cE, types(TypeEscString), ""},
+	{"labeltooltip", cE, types(TypeEscString), ""},
+	{"labelURL", cE, types(TypeEscString), ""},
+	{"landscape", cG, types(TypeBool), "false"},
+	{"layer", cC | cN | cE, types(TypeLayerRange), ""},
+	{"layerlistsep", cG, types(TypeString), ","},
+	{"layers", cG, types(TypeLayerList), ""},
+	{"layerselect", cG, types(TypeLayerRange), ""},
+	{"layersep", cG, types(TypeString), ":\t "},
+	{"layout", cG, types(TypeString), ""},
+	{"len", cE, types(TypeDouble), "1.0"},
+	{"levels", cG, types(TypeInt), ""},
+	{"levelsgap", cG, types(TypeDouble), "0.0"},
+	{"lhead", cE, types(TypeString), ""},
+	{"lheight", cG | cC, types(TypeDouble), ""},
+	{"linelength", cG, types(TypeInt), "128"},
+	{"lp", cG | cC | cE, types(TypePoint), ""},
+	{"ltail", cE, types(TypeString), ""},
+	{"lwidth", cG | cC, types(TypeDouble), ""},
+	{"margin", cG | cC | cN, types(TypeDouble, TypePoint), ""},
+	{"maxiter", cG, types(TypeInt), ""},
+	{"mclimit", cG, types(TypeDouble), "1.0"},
+	{"mindist", cG, types(TypeDouble), "1.0"},
+	{"minlen", cE, types(TypeInt), "1"},
+	{"mode", cG, types(TypeString), "major"},
+	{"model", cG, types(TypeString), "shortpath"},
+	{"newrank", cG, types(TypeBool), "false"},
+	{"nodesep", cG, types(TypeDouble), "0.25"},
+	{"nojustify", cG | cC | cN | cE, types(TypeBool), "false"},
+	{"normalize", cG, types(TypeDouble, TypeBool), "false"},
+	{"notranslate", cG, types(TypeBool), "false"},
+	{"nslimit", cG, types(TypeDouble), ""},
+	{"nslimit1", cG, types(TypeDouble), ""},
+	{"oneblock", cG, types(TypeBool), "false"},
+	{"ordering", cG | cN, types(TypeString), ""},
+	{"orientation", cG | cN, types(TypeDouble, TypeString), ""},
+	{"outputorder", cG, types(TypeOutputMode), "breadthfirst"},
+	{"overlap", cG, types(TypeString, TypeBool), "true"},
+	{"overlap_scaling", cG, types(TypeDouble), "-4"},
+	{"overlap_shrink", cG, types(TypeBool), "true"},
+	{"pack", cG, types(TypeBool, TypeInt), "false"},
+	{"packmode", cG, types(TypePackMode), "node"},
+	{"pad", cG, types(TypeDouble, TypePoint), "0.0555"},
+	{"page", cG, types(TypeDouble, TypePoint), ""},
+	{"pagedir", cG, types(TypePageDir), "BL"},
+	{"pencolor", cC, types(TypeColor), "black"},
+	{"penwidth", cC | cN | cE, types(TypeDouble), "1.0"},
+	{"peripheries", cC | cN, types(TypeInt), ""},
+	{"pin", cN, types(TypeBool), "false"},
+	{"pos", cN | cE, types(TypePoint, TypeSplineType), ""},
+	{"quadtree", cG, types(TypeQuadType, TypeBool), "normal"},
+	{"quantum", cG, types(TypeDouble), "0.0"},
+	{"rank", cS, types(TypeRankType), ""},
+	{"rankdir", cG, types(TypeRankDir), "TB"},
+	{"ranksep", cG, types(TypeDouble, TypeDoubleList), "0.5"},
+	{"ratio", cG, types(TypeDouble, TypeString), ""},
+	{"rects", cN, types(TypeRect), ""},
+	{"regular", cN, types(TypeBool), "false"},
+	{"remincross", cG, types(TypeBool), "true"},
+	{"repulsiveforce", cG, types(TypeDouble), "1.0"},
+	{"resolution", cG, types(TypeDouble), "96.0"},
+	{"root", cG | cN, types(TypeString, TypeBool), ""},
+	{"rotate", cG, types(TypeInt), "0"},
+	{"rotation", cG, types(TypeDouble), "0"},
+	{"samehead", cE, types(TypeString), ""},
+	{"sametail", cE, types(TypeString), ""},
+	{"samplepoints", cN, types(TypeInt), "8"},
+	{"scale", cG, types(TypeDouble, TypePoint), ""},
+	{"searchsize", cG, types(TypeInt), "30"},
+	{"sep", cG, types(TypeAddDouble, TypeAddPoint), "+4"},
+	{"shape", cN, types(TypeShape), "ellipse"},
+	{"shapefile", cN, types(TypeString), ""},
+	{"showboxes", cG | cN | cE, types(TypeInt), "0"},
+	{"sides", cN, types(TypeInt), "4"},
+	{"size", cG, types(TypeDouble, TypePoint), ""},
+	{"skew", cN, types(TypeDouble), "0.0"},
+	{"smoothing", cG, types(TypeSmoothType), "none"},
+	{"sortv", cG | cC | cN, types(TypeInt), "0"},
+	{"splines", cG, types(TypeBool, TypeString), ""},
+	{"start", cG, types(TypeStartType), ""},
+	{"style", cG | cC | cN | cE, types(TypeStyle), ""},
+	{"stylesheet", cG, types(TypeString), ""},
+	{"tail_lp", cE, types(TypePoint), ""},
+	{"tailclip", cE, types(TypeBool), "true"},
+	{"tailhref", cE, types(TypeEscString), ""},
+	{"taillabel", cE, types(TypeLblString), ""},
+	{"tailport", cE, types(TypePortPos), "center"},
+	{"tailtarget", cE, types(TypeEscString), ""},
+	{"tailtooltip", cE, types(TypeEscString), ""},
+	{"tailURL", cE, types(TypeEscString), ""},
+	{"target", cG | cC | cN | cE, types(TypeEscString, TypeString), ""},
+	{"TBbalance", cG, types(TypeString), ""},
+	{"tooltip", cG | cC | cN | cE, types(TypeEscString), ""},
+	{"truecolor", cG, types(TypeBool), ""},
+	{"URL", cG | cC | cN | cE, types(TypeEscString), ""},
+	{"vertices", cN, types(TypePointList), ""},
+	{"viewport", cG, types(TypeViewPort), ""},
+	{"voro_margin", cG, types(TypeDouble), "0.05"},
+	{"weight", cE, types(TypeInt, TypeDouble), "1"},
+	{"width", cN, types(TypeDouble), "0.75"},
+	{"xdotversion", cG, types(TypeString), ""},
+	{"xlabel", cN | cE, types(TypeLblString), ""},
+	{"xlp", cN | cE, types(TypePoint), ""},
+	{"z", cN, types(TypeDouble), "0.0"},
+}
+
+// attrSpecMap maps from attribute names to their specification.
+var attrSpecMap = make(map[string]*AttrSpec)
+
+func init() {
+	for _, spec := range attrSpecs {
+		attrSpecMap[spec.Name] = spec
+		// Clusters are subgraphs, so cluster attributes may be set on any
+		// subgraph.
+		if spec.Contexts&cC != 0 {
+			spec.Contexts |= cS
+		}
+	}
+}
+
+// LookupAttr returns the specification of the named Graphviz attribute.
+func LookupAttr(name string) (*AttrSpec, bool) {
+	spec, ok := attrSpecMap[name]
+	return spec, ok
+}
+
+// AttrNames returns the names of all Graphviz attributes, in sorted order.
+func AttrNames() []string {
+	names := make([]string, 0, len(attrSpecs))
+	for _, spec := range attrSpecs {
+		names = append(names, spec.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateAttr reports an error if the named attribute is unknown, does not
+// apply to the given context, or if the given value is invalid.
+func ValidateAttr(ctx Context, name, value string) error {
+	return validateAttr(ctx, name, value, false)
+}
+
+// validateAttr validates an attribute as with ValidateAttr, whose value is an
+// HTML string if html is true.
+func validateAttr(ctx Context, name, value string, html bool) error {
+	spec, ok := LookupAttr(name)
+	if !ok {
+		return fmt.Errorf("unknown attribute %q", name)
+	}
+	if spec.Contexts&ctx == 0 {
+		return fmt.Errorf("attribute %q does not apply to %v (valid contexts: %v)", name, ctx, spec.Contexts)
+	}
+	if html {
+		// HTML strings are only valid for labels.
+		for _, t := range spec.Types {
+			if t == TypeLblString {
+				return nil
+			}
+		}
+		return fmt.Errorf("HTML string not valid for attribute %q", name)
+	}
+	if err := spec.Validate(value); err != nil {
+		return err
+	}
+	if name == "style" {
+		return validateStyle(ctx, value)
+	}
+	return nil
+}
+
+// validateStyle reports an error if any item of the given style does not apply
+// to the given context. Styles with arguments, such as setlinewidth(2), are
+// not checked.
+func validateStyle(ctx Context, value string) error {
+	var valid []string
+	switch ctx {
+	case ContextNode:
+		valid = nodeStyles
+	case ContextEdge:
+		valid = edgeStyles
+	case ContextCluster:
+		valid = clusterStyles
+	default:
+		return nil
+	}
+	items, err := parseStyle(value)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if len(item.Args) == 0 && item.Name != "setlinewidth" && !contains(valid, item.Name) {
+			return fmt.Errorf("style %q does not apply to %v; expected one of %s", item.Name, ctx, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package dot
+
+import "testing"
+
+func TestValidateAttr(t *testing.T) {
+	tests := []struct {
+		ctx   Context
+		name  string
+		value string
+		valid bool
+	}{
+		{ContextNode, "shape", "box", true},
+		{ContextNode, "shape", "Mrecord", true},
+		{ContextNode, "shape", "boxx", false},
+		{ContextEdge, "shape", "box", false},
+		{ContextEdge, "penwidth", "2.5", true},
+		{ContextEdge, "penwidth", "2.5", true},
+		{ContextEdge, "penwidth", "thick", false},
+		{ContextEdge, "arrowhead", "olvee", true},
+		{ContextEdge, "arrowhead", "invodot", true},
+		{ContextEdge, "arrowhead", "ediamond", true},
+		{ContextEdge, "arrowhead", "arrow", false},
+		{ContextEdge, "dir", "both", true},
+		{ContextEdge, "dir", "up", false},
+		{ContextEdge, "weight", "0.5", true},
+		{ContextEdge, "constraint", "false", true},
+		{ContextEdge, "constraint", "maybe", false},
+		{ContextGraph, "rankdir", "LR", true},
+		{ContextGraph, "rankdir", "lr", false},
+		{ContextGraph, "size", "7.5,10", true},
+		{ContextGraph, "size", "7.5,", false},
+		{ContextGraph, "ranksep", "1.0:2.0", true},
+		{ContextGraph, "bb", "0,0,100,200", true},
+		{ContextGraph, "packmode", "array_tr3", true},
+		{ContextSubGraph, "rank", "same", true},
+		{ContextCluster, "bgcolor", "#ff000080", true},
+		{ContextCluster, "bgcolor", "red:blue;0.3", true},
+		{ContextCluster, "bgcolor", "#ff00", false},
+		{ContextNode, "color", "0.1 0.2 0.3", true},
+		{ContextNode, "color", "/accent3/1", true},
+		{ContextNode, "label", "<<b>x</b>>", true},
+		{ContextNode, "width", "<<b>x</b>>", false},
+		{ContextNode, "style", "filled,rounded", true},
+		{ContextNode, "style", "setlinewidth(2),dashed", true},
+		{ContextEdge, "style", "filled", false},
+		{ContextNode, "colour", "red", false},
+	}
+	for _, test := range tests {
+		err := ValidateAttr(test.ctx, test.name, test.value)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("%v %s=%s: expected valid %v, got error %v", test.ctx, test.name, test.value, test.valid, err)
+		}
+	}
+	// HTML strings are only valid for labels.
+	check(t, validateAttr(ContextNode, "label", "<<b>x</b>>", true))
+	if err := validateAttr(ContextNode, "tooltip", "<<b>x</b>>", true); err == nil {
+		t.Errorf("tooltip: expected error for HTML string")
+	}
+}
+
+func TestAttrSpec(t *testing.T) {
+	spec, ok := LookupAttr("penwidth")
+	assert(t, "found", ok, true)
+	assert(t, "contexts", spec.Contexts.String(), "SCNE")
+	assert(t, "types", spec.Types[0].String(), "double")
+	assert(t, "default", spec.Default, "1.0")
+	assert(t, "subgraph context", SubGraphContext("cluster_x"), ContextCluster)
+	_, ok = LookupAttr("penWidth")
+	assert(t, "unknown", ok, false)
+}
+
+func TestTypedAttrs(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		rankdir=LR;
+		subgraph s { rank=same; a; }
+		a [shape=box, label="a node", penwidth=2];
+		b;
+		a -> b [penwidth=x, minlen=2];
+		b -> a;
+	}`))
+	check(t, err)
+	a, b := g.Nodes.Lookup["a"], g.Nodes.Lookup["b"]
+
+	shape, err := a.Shape()
+	check(t, err)
+	assert(t, "shape", shape, "box")
+	shape, err = b.Shape()
+	check(t, err)
+	assert(t, "default shape", shape, "ellipse")
+	assert(t, "label", a.Label(), "a node")
+	assert(t, "default node label", b.Label(), `\N`)
+	assert(t, "default graph label", g.Label(), "")
+	penwidth, err := a.Penwidth()
+	check(t, err)
+	assert(t, "node penwidth", penwidth, 2.0)
+
+	ab, ba := g.Edges.SrcToDsts["a"]["b"], g.Edges.SrcToDsts["b"]["a"]
+	if _, err := ab.Penwidth(); err == nil {
+		t.Errorf("expected error for invalid penwidth")
+	}
+	minlen, err := ab.Minlen()
+	check(t, err)
+	assert(t, "minlen", minlen, 2)
+	penwidth, err = ba.Penwidth()
+	check(t, err)
+	assert(t, "default penwidth", penwidth, 1.0)
+	dir, err := ba.DirType()
+	check(t, err)
+	assert(t, "dir", dir, "forward")
+
+	rankdir, err := g.Rankdir()
+	check(t, err)
+	assert(t, "rankdir", rankdir, "LR")
+	rank, err := g.SubGraphs.SubGraphs["s"].Rank()
+	check(t, err)
+	assert(t, "rank", rank, "same")
+
+	// Setters validate values and quote them if required.
+	check(t, b.SetShape("circle"))
+	assert(t, "set shape", b.Attrs["shape"], "circle")
+	check(t, b.SetLabel("b node"))
+	assert(t, "set label", b.Attrs["label"], "b node")
+	check(t, ba.SetPenwidth(0.5))
+	assert(t, "set penwidth", ba.Attrs["penwidth"], "0.5")
+	if err := b.SetShape("blob"); err == nil {
+		t.Errorf("expected error for invalid shape")
+	}
+	if err := ba.SetAttr("shape", "box"); err == nil {
+		t.Errorf("expected error for node attribute on edge")
+	}
+	if err := g.SetRankdir("sideways"); err == nil {
+		t.Errorf("expected error for invalid rankdir")
+	}
+	assert(t, "unchanged shape", b.Attrs["shape"], "circle")
+}
@@ -0,0 +1,257 @@
+package dot
+
+// This file defines set operations on graphs.
+
+import "fmt"
+
+// ConflictPolicy resolves conflicting values of an attribute present in both
+// the left and the right graph of a set operation, returning the value to use
+// or an error.
+type ConflictPolicy func(name, left, right string) (string, error)
+
+// Conflict policies.
+var (
+	// KeepLeft resolves conflicts using the value of the left graph.
+	KeepLeft ConflictPolicy = func(name, left, right string) (string, error) {
+		return left, nil
+	}
+	// KeepRight resolves conflicts using the value of the right graph.
+	KeepRight ConflictPolicy = func(name, left, right string) (string, error) {
+		return right, nil
+	}
+	// ErrorOnConflict fails on conflicting values.
+	ErrorOnConflict ConflictPolicy = func(name, left, right string) (string, error) {
+		return "", fmt.Errorf("conflicting values %q and %q of attribute %q", left, right, name)
+	}
+)
+
+// mergeAttrs adds the attributes of right to left, along with their HTML
+// attributes, resolving conflicts using the given policy. If common is set,
+// only attributes present in both are retained. A merged value is an HTML
+// string if taken from an HTML string.
+func mergeAttrs(left Attrs, leftHTML *HTMLAttrs, right Attrs, rightHTML HTMLAttrs, policy ConflictPolicy, common bool) error {
+	if common {
+		for name := range left {
+			if _, ok := right[name]; !ok {
+				delete(left, name)
+				leftHTML.set(name, false)
+			}
+		}
+	}
+	for _, name := range right.SortedNames() {
+		value := right[name]
+		prev, ok := left[name]
+		html := (*leftHTML)[name]
+		switch {
+		case !ok:
+			if common {
+				continue
+			}
+			html = rightHTML[name]
+		case prev != value:
+			resolved, err := policy(name, prev, value)
+			if err != nil {
+				return err
+			}
+			if resolved != prev {
+				html = resolved == value && rightHTML[name]
+			}
+			value = resolved
+		}
+		left[name] = value
+		leftHTML.set(name, html)
+	}
+	return nil
+}
+
+// edgeMatcher matches the edges of a graph by the names of their endpoints,
+// with parallel edges matched in edge order.
+type edgeMatcher map[string][]*Edge
+
+// newEdgeMatcher returns an edge matcher for the given edges.
+func newEdgeMatcher(edges []*Edge) edgeMatcher {
+	m := make(edgeMatcher)
+	for _, edge := range edges {
+		key := edgeKey(edge)
+		m[key] = append(m[key], edge)
+	}
+	return m
+}
+
+// match returns the first unmatched edge with the same endpoints as edge, or
+// nil if none.
+func (m edgeMatcher) match(edge *Edge) *Edge {
+	key := edgeKey(edge)
+	if len(m[key]) == 0 {
+		return nil
+	}
+	e := m[key][0]
+	m[key] = m[key][1:]
+	return e
+}
+
+// Union returns a new graph containing the nodes, edges and subgraphs of both
+// a and b, along with the subgraph membership of each node. Nodes and
+// subgraphs are matched by name, and edges by the names of their endpoints.
+// Conflicting attribute values are resolved using the given policy. The new
+// graph has the name and type of a.
+func Union(a, b *Graph, policy ConflictPolicy) (*Graph, error) {
+	g := copyGraph(a, nil)
+	for _, edge := range a.Edges.Edges {
+		copyEdge(g, edge)
+	}
+	if err := mergeAttrs(g.Attrs, &g.HTMLAttrs, b.Attrs, b.HTMLAttrs, policy, false); err != nil {
+		return nil, fmt.Errorf("graph %q: %v", g.Name, err)
+	}
+	for _, sub := range b.SubGraphs.Sorted() {
+		if s, ok := g.SubGraphs.SubGraphs[sub.Name]; ok {
+			if err := mergeAttrs(s.Attrs, &s.HTMLAttrs, sub.Attrs, sub.HTMLAttrs, policy, false); err != nil {
+				return nil, fmt.Errorf("subgraph %q: %v", sub.Name, err)
+			}
+			continue
+		}
+		copySubGraph(g, b, sub.Name)
+	}
+	for _, node := range b.Nodes.Nodes {
+		if n, ok := g.Nodes.Lookup[node.Name]; ok {
+			if n.Attrs == nil {
+				n.Attrs = NewAttrs()
+			}
+			if err := mergeAttrs(n.Attrs, &n.HTMLAttrs, node.Attrs, node.HTMLAttrs, policy, false); err != nil {
+				return nil, fmt.Errorf("node %q: %v", node.Name, err)
+			}
+		} else {
+			g.Nodes.Add(&Node{Name: node.Name, Attrs: node.Attrs.Copy(), HTMLAttrs: node.HTMLAttrs.Copy()})
+		}
+		for _, parent := range sortedKeys(b.Relations.ChildToParents[node.Name]) {
+			if parent == b.Name {
+				parent = g.Name
+			}
+			g.Relations.Add(parent, node.Name)
+		}
+	}
+	m := newEdgeMatcher(g.Edges.Edges)
+	for _, edge := range b.Edges.Edges {
+		e := m.match(edge)
+		if e == nil {
+			copyEdge(g, edge)
+			continue
+		}
+		if e.Attrs == nil {
+			e.Attrs = NewAttrs()
+		}
+		if err := mergeAttrs(e.Attrs, &e.HTMLAttrs, edge.Attrs, edge.HTMLAttrs, policy, false); err != nil {
+			return nil, fmt.Errorf("edge %s: %v", edgeString(edge), err)
+		}
+	}
+	linkNodes(g)
+	return g, nil
+}
+
+// Intersection returns a new graph containing the nodes, edges and subgraphs
+// present in both a and b, along with the subgraph memberships present in
+// both. Nodes and subgraphs are matched by name, and edges by the names of
+// their endpoints. Only attributes present in both graphs are retained, and
+// conflicting attribute values are resolved using the given policy. The new
+// graph has the name and type of a.
+func Intersection(a, b *Graph, policy ConflictPolicy) (*Graph, error) {
+	g := NewGraph()
+	g.Name = a.Name
+	g.Directed = a.Directed
+	g.Strict = a.Strict
+	g.Attrs = a.Attrs.Copy()
+	g.HTMLAttrs = a.HTMLAttrs.Copy()
+	if err := mergeAttrs(g.Attrs, &g.HTMLAttrs, b.Attrs, b.HTMLAttrs, policy, true); err != nil {
+		return nil, fmt.Errorf("graph %q: %v", g.Name, err)
+	}
+	for _, sub := range a.SubGraphs.Sorted() {
+		other, ok := b.SubGraphs.SubGraphs[sub.Name]
+		if !ok {
+			continue
+		}
+		g.SubGraphs.Add(sub.Name)
+		s := g.SubGraphs.SubGraphs[sub.Name]
+		s.Attrs = sub.Attrs.Copy()
+		s.HTMLAttrs = sub.HTMLAttrs.Copy()
+		if err := mergeAttrs(s.Attrs, &s.HTMLAttrs, other.Attrs, other.HTMLAttrs, policy, true); err != nil {
+			return nil, fmt.Errorf("subgraph %q: %v", sub.Name, err)
+		}
+	}
+	for _, sub := range g.SubGraphs.SubGraphs {
+		sub.Parent = subGraphParent(g, a, sub.Name)
+	}
+	for _, node := range a.Nodes.Nodes {
+		other, ok := b.Nodes.Lookup[node.Name]
+		if !ok {
+			continue
+		}
+		n := &Node{Name: node.Name, Attrs: node.Attrs.Copy(), HTMLAttrs: node.HTMLAttrs.Copy()}
+		if err := mergeAttrs(n.Attrs, &n.HTMLAttrs, other.Attrs, other.HTMLAttrs, policy, true); err != nil {
+			return nil, fmt.Errorf("node %q: %v", node.Name, err)
+		}
+		g.Nodes.Add(n)
+		for _, parent := range sortedKeys(a.Relations.ChildToParents[node.Name]) {
+			if parent == a.Name {
+				if b.Relations.ChildToParents[node.Name][b.Name] {
+					g.Relations.Add(g.Name, node.Name)
+				}
+			} else if b.Relations.ChildToParents[node.Name][parent] {
+				g.Relations.Add(parent, node.Name)
+			}
+		}
+	}
+	m := newEdgeMatcher(b.Edges.Edges)
+	for _, edge := range a.Edges.Edges {
+		other := m.match(edge)
+		if other == nil {
+			continue
+		}
+		copyEdge(g, edge)
+		e := g.Edges.Edges[len(g.Edges.Edges)-1]
+		if err := mergeAttrs(e.Attrs, &e.HTMLAttrs, other.Attrs, other.HTMLAttrs, policy, true); err != nil {
+			return nil, fmt.Errorf("edge %s: %v", edgeString(edge), err)
+		}
+	}
+	linkNodes(g)
+	return g, nil
+}
+
+// Difference returns a new graph containing the edges of a not present in b,
+// and the nodes of a which are either not present in b or endpoints of such
+// edges, along with the subgraphs containing them. Nodes are matched by name,
+// and edges by the names of their endpoints. Attributes are taken from a.
+func Difference(a, b *Graph) *Graph {
+	keep := make(map[string]bool)
+	for _, node := range a.Nodes.Nodes {
+		if !b.IsNode(node.Name) {
+			keep[node.Name] = true
+		}
+	}
+	var edges []*Edge
+	m := newEdgeMatcher(b.Edges.Edges)
+	for _, edge := range a.Edges.Edges {
+		if m.match(edge) != nil {
+			continue
+		}
+		edges = append(edges, edge)
+		for _, name := range []string{edge.Src, edge.Dst} {
+			for _, node := range a.endpoints(name) {
+				keep[node.Name] = true
+			}
+		}
+	}
+	g := copyGraph(a, func(node *Node) bool {
+		return keep[node.Name]
+	})
+	for _, edge := range edges {
+		// Retain subgraph endpoints without nodes.
+		for _, name := range []string{edge.Src, edge.Dst} {
+			if a.IsSubGraph(name) {
+				copySubGraph(g, a, name)
+			}
+		}
+		copyEdge(g, edge)
+	}
+	linkNodes(g)
+	return g
+}
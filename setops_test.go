@@ -0,0 +1,87 @@
+package dot
+
+import "testing"
+
+const (
+	serviceA = `digraph system {
+		subgraph cluster_a { label=A; api; db; }
+		api -> db [label=sql];
+		api -> auth;
+		auth [shape=box];
+	}`
+	serviceB = `digraph b {
+		subgraph cluster_b { label=B; worker; }
+		worker -> auth;
+		api -> auth [color=blue];
+		auth [shape=ellipse];
+	}`
+)
+
+func TestUnion(t *testing.T) {
+	a, err := Read([]byte(serviceA))
+	check(t, err)
+	b, err := Read([]byte(serviceB))
+	check(t, err)
+	if _, err := Union(a, b, ErrorOnConflict); err == nil {
+		t.Fatalf("expected conflict error")
+	}
+	g, err := Union(a, b, KeepRight)
+	check(t, err)
+	assert(t, "name", g.Name, "system")
+	assert(t, "nodes", nodeNames(g.Nodes.Nodes), "[api db auth worker]")
+	assert(t, "edges", edgeNames(g), "[api->db api->auth worker->auth]")
+	assert(t, "node attribute", g.Nodes.Lookup["auth"].Attrs["shape"], "ellipse")
+	assert(t, "edge attribute", g.Edges.SrcToDsts["api"]["auth"].Attrs["color"], "blue")
+	assert(t, "subgraph membership", g.Relations.ParentToChildren["cluster_a"]["db"], true)
+	assert(t, "subgraph membership", g.Relations.ParentToChildren["cluster_b"]["worker"], true)
+	assert(t, "subgraph attribute", g.SubGraphs.SubGraphs["cluster_b"].Attrs["label"], "B")
+
+	custom := func(name, left, right string) (string, error) {
+		return left + "/" + right, nil
+	}
+	g, err = Union(a, b, custom)
+	check(t, err)
+	assert(t, "node attribute", g.Nodes.Lookup["auth"].Attrs["shape"], "box/ellipse")
+	// The input graphs are left unmodified.
+	assert(t, "node attribute", a.Nodes.Lookup["auth"].Attrs["shape"], "box")
+}
+
+func TestIntersection(t *testing.T) {
+	a, err := Read([]byte(serviceA))
+	check(t, err)
+	b, err := Read([]byte(serviceB))
+	check(t, err)
+	g, err := Intersection(a, b, KeepLeft)
+	check(t, err)
+	assert(t, "nodes", nodeNames(g.Nodes.Nodes), "[api auth]")
+	assert(t, "edges", edgeNames(g), "[api->auth]")
+	assert(t, "node attribute", g.Nodes.Lookup["auth"].Attrs["shape"], "box")
+	assert(t, "edge attribute", g.Edges.SrcToDsts["api"]["auth"].Attrs["color"], "")
+}
+
+func TestDifference(t *testing.T) {
+	a, err := Read([]byte(serviceA))
+	check(t, err)
+	b, err := Read([]byte(serviceB))
+	check(t, err)
+	g := Difference(a, b)
+	assert(t, "nodes", nodeNames(g.Nodes.Nodes), "[api db]")
+	assert(t, "edges", edgeNames(g), "[api->db]")
+	assert(t, "subgraph membership", g.Relations.ParentToChildren["cluster_a"]["api"], true)
+}
+
+func TestSetOpsNesting(t *testing.T) {
+	// Derived graphs retain the nesting of subgraphs.
+	a, err := Read([]byte(`digraph A { x subgraph cluster_c { subgraph cluster_d { y } } }`))
+	check(t, err)
+	b, err := Read([]byte(`digraph B { subgraph cluster_b { subgraph cluster_a { y } } subgraph cluster_c { subgraph cluster_d { y } } }`))
+	check(t, err)
+	u, err := Union(a, b, KeepLeft)
+	check(t, err)
+	assert(t, "union outer", u.SubGraphs.SubGraphs["cluster_b"].Parent, "A")
+	assert(t, "union inner", u.SubGraphs.SubGraphs["cluster_a"].Parent, "cluster_b")
+	i, err := Intersection(a, b, KeepLeft)
+	check(t, err)
+	assert(t, "intersection outer", i.SubGraphs.SubGraphs["cluster_c"].Parent, "A")
+	assert(t, "intersection inner", i.SubGraphs.SubGraphs["cluster_d"].Parent, "cluster_c")
+}
@@ -16,12 +16,16 @@ package dot
 
 import (
 	"sort"
+	"strings"
 )
 
 //Represents a Subgraph.
 type SubGraph struct {
-	Attrs Attrs
-	Name  string
+	Attrs     Attrs
+	HTMLAttrs HTMLAttrs
+	Name      string
+	// Name of the graph or subgraph containing the subgraph.
+	Parent string
 }
 
 //Creates a new Subgraph.
@@ -61,3 +65,103 @@ func (this *SubGraphs) Sorted() []*SubGraph {
 	}
 	return s
 }
+
+// isAnonymous reports whether the given subgraph name is generated by the
+// parser for an anonymous subgraph; i.e. "anon" followed by digits.
+func isAnonymous(name string) bool {
+	digits := strings.TrimPrefix(name, "anon")
+	if len(digits) == len(name) || digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// childSubGraphs returns the sorted names of the subgraphs directly contained
+// in the named graph or subgraph.
+func childSubGraphs(g *Graph, parent string) []string {
+	var names []string
+	for _, sub := range g.SubGraphs.Sorted() {
+		if sub.Name == parent {
+			continue
+		}
+		if sub.Parent == parent || (parent == g.Name && !g.IsSubGraph(sub.Parent)) {
+			names = append(names, sub.Name)
+		}
+	}
+	return names
+}
+
+// subGraphNodes returns the names of the nodes of the named subgraph,
+// including those of nested subgraphs, in the order of the nodes of the graph.
+func subGraphNodes(g *Graph, name string) []string {
+	set := make(map[string]bool)
+	var add func(sub string)
+	add = func(sub string) {
+		for _, child := range g.Relations.SortedChildren(sub) {
+			if g.IsNode(child) {
+				set[child] = true
+			}
+		}
+		for _, child := range childSubGraphs(g, sub) {
+			add(child)
+		}
+	}
+	add(name)
+	var nodes []string
+	for _, node := range g.Nodes.Nodes {
+		if set[node.Name] {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	return nodes
+}
+
+// edgeEndpoints returns the nodes of the given edge endpoint, which is either
+// a node or a subgraph.
+func edgeEndpoints(g *Graph, name string) []string {
+	if g.IsSubGraph(name) {
+		return subGraphNodes(g, name)
+	}
+	return []string{name}
+}
+
+// nodeSubGraph returns the name of the subgraph depicting the named node; i.e.
+// the innermost cluster containing it, or otherwise the innermost subgraph
+// containing it, or the name of the graph if none.
+func nodeSubGraph(g *Graph, name string) string {
+	return innermostSubGraph(g, sortedKeys(g.Relations.ChildToParents[name]))
+}
+
+// innermostSubGraph returns the innermost cluster of the given names, or
+// otherwise the innermost subgraph, or the name of the graph if none are
+// subgraphs. Ties are broken by the order of the names.
+func innermostSubGraph(g *Graph, names []string) string {
+	best, bestDepth, bestCluster := g.Name, -1, false
+	for _, name := range names {
+		if !g.IsSubGraph(name) {
+			continue
+		}
+		cluster := SubGraphContext(name) == ContextCluster
+		depth := subGraphDepth(g, name)
+		if (cluster && !bestCluster) || (cluster == bestCluster && depth > bestDepth) {
+			best, bestDepth, bestCluster = name, depth, cluster
+		}
+	}
+	return best
+}
+
+// subGraphDepth returns the number of subgraphs enclosing the named subgraph.
+func subGraphDepth(g *Graph, name string) int {
+	seen := map[string]bool{name: true}
+	depth := 0
+	for parent := g.SubGraphs.SubGraphs[name].Parent; g.IsSubGraph(parent) && !seen[parent]; parent = g.SubGraphs.SubGraphs[parent].Parent {
+		seen[parent] = true
+		depth++
+	}
+	return depth
+}
@@ -0,0 +1,27 @@
+package dot
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubGraphParent(t *testing.T) {
+	// Subgraphs added again keep the parent of their first addition.
+	g, err := Read([]byte(`digraph G { subgraph s { a } subgraph t { subgraph s { b } } }`))
+	check(t, err)
+	assert(t, "parent", g.SubGraphs.SubGraphs["s"].Parent, "G")
+	assert(t, "member", g.Relations.ChildToParents["b"]["s"], true)
+	g.AddSubGraph("t", "s", map[string]string{"color": "red"})
+	assert(t, "parent kept", g.SubGraphs.SubGraphs["s"].Parent, "G")
+	assert(t, "attrs merged", g.SubGraphs.SubGraphs["s"].Attrs["color"], "red")
+}
+
+func TestSubGraphNodes(t *testing.T) {
+	g, err := Read([]byte(`digraph G { subgraph cluster_a { a subgraph cluster_b { b } } c }`))
+	check(t, err)
+	assert(t, "children", fmt.Sprint(childSubGraphs(g, "G")), fmt.Sprint([]string{"cluster_a"}))
+	assert(t, "nested children", fmt.Sprint(childSubGraphs(g, "cluster_a")), fmt.Sprint([]string{"cluster_b"}))
+	assert(t, "nodes", fmt.Sprint(subGraphNodes(g, "cluster_a")), fmt.Sprint([]string{"a", "b"}))
+	assert(t, "node endpoint", fmt.Sprint(edgeEndpoints(g, "c")), fmt.Sprint([]string{"c"}))
+	assert(t, "subgraph endpoint", fmt.Sprint(edgeEndpoints(g, "cluster_b")), fmt.Sprint([]string{"b"}))
+}
@@ -0,0 +1,211 @@
+package dot
+
+// This file defines depth-first traversal orders and edge classification.
+
+// Arc is a directed connection between two nodes of a graph, as derived from
+// an edge. Undirected edges give rise to an arc in each direction, and edges
+// to or from a subgraph connect each node of the subgraph.
+type Arc struct {
+	Src, Dst *Node
+	// Edge from which the arc was derived.
+	Edge *Edge
+}
+
+// Arcs returns the arcs of the graph, in edge order.
+func (g *Graph) Arcs() []Arc {
+	var arcs []Arc
+	for _, edge := range g.Edges.Edges {
+		for _, src := range g.endpoints(edge.Src) {
+			for _, dst := range g.endpoints(edge.Dst) {
+				arcs = append(arcs, Arc{Src: src, Dst: dst, Edge: edge})
+				if !edge.Dir && src != dst {
+					arcs = append(arcs, Arc{Src: dst, Dst: src, Edge: edge})
+				}
+			}
+		}
+	}
+	return arcs
+}
+
+// endpoints returns the nodes denoted by the given edge endpoint, which is
+// either the name of a node or the name of a subgraph, including the nodes of
+// its nested subgraphs.
+func (g *Graph) endpoints(name string) []*Node {
+	if node, ok := g.Nodes.Lookup[name]; ok {
+		return []*Node{node}
+	}
+	var nodes []*Node
+	for _, child := range subGraphNodes(g, name) {
+		nodes = append(nodes, g.Nodes.Lookup[child])
+	}
+	return nodes
+}
+
+// outArcs returns the outgoing arcs of each node of the graph, indexed by
+// Node.Index.
+func (g *Graph) outArcs() [][]Arc {
+	out := make([][]Arc, len(g.Nodes.Nodes))
+	for _, arc := range g.Arcs() {
+		out[arc.Src.Index] = append(out[arc.Src.Index], arc)
+	}
+	return out
+}
+
+// EdgeKind classifies an arc with regards to a depth-first spanning tree.
+type EdgeKind int
+
+// Edge kinds.
+const (
+	// TreeEdge is part of the depth-first spanning tree.
+	TreeEdge EdgeKind = iota
+	// BackEdge leads from a node to one of its ancestors, or to itself.
+	BackEdge
+	// ForwardEdge leads from a node to a non-child descendant.
+	ForwardEdge
+	// CrossEdge leads to a node which is neither an ancestor nor a descendant.
+	CrossEdge
+)
+
+func (kind EdgeKind) String() string {
+	switch kind {
+	case TreeEdge:
+		return "tree"
+	case BackEdge:
+		return "back"
+	case ForwardEdge:
+		return "forward"
+	case CrossEdge:
+		return "cross"
+	}
+	return "unknown"
+}
+
+// ClassifiedArc is an arc visited by a depth-first traversal.
+type ClassifiedArc struct {
+	Arc
+	Kind EdgeKind
+}
+
+// DFS is the result of a depth-first traversal of a graph from a given root.
+// Nodes not reachable from the root are not part of the traversal.
+type DFS struct {
+	// Root of the traversal.
+	Root *Node
+	// Preorder contains the visited nodes in depth-first preorder.
+	Preorder []*Node
+	// Postorder contains the visited nodes in depth-first postorder.
+	Postorder []*Node
+	// Arcs contains the classified arcs leaving each visited node, in
+	// traversal order. The reverse direction of an undirected tree or back
+	// edge is omitted, so that each undirected edge is classified once.
+	Arcs []ClassifiedArc
+
+	// Each slice is indexed by Node.Index; -1 if not visited.
+	pre, post []int
+	// parent contains the tree arc leading to each node, indexed by Node.Index.
+	parent []*Edge
+}
+
+// DFS performs a depth-first traversal of the graph from root. Successors are
+// visited in edge order.
+func (g *Graph) DFS(root *Node) *DFS {
+	n := len(g.Nodes.Nodes)
+	d := &DFS{
+		Root:   root,
+		pre:    make([]int, n),
+		post:   make([]int, n),
+		parent: make([]*Edge, n),
+	}
+	for i := range d.pre {
+		d.pre[i] = -1
+		d.post[i] = -1
+	}
+	d.visit(root, g.outArcs())
+	return d
+}
+
+// visit implements the recursive part of the depth-first traversal.
+func (d *DFS) visit(v *Node, out [][]Arc) {
+	d.pre[v.Index] = len(d.Preorder)
+	d.Preorder = append(d.Preorder, v)
+	for _, arc := range out[v.Index] {
+		w := arc.Dst
+		undirected := !arc.Edge.Dir
+		var kind EdgeKind
+		switch {
+		case d.pre[w.Index] == -1:
+			kind = TreeEdge
+		case undirected && d.parent[v.Index] == arc.Edge && w != v:
+			// Reverse direction of the tree edge leading to v.
+			continue
+		case d.post[w.Index] == -1:
+			kind = BackEdge
+		case d.pre[v.Index] < d.pre[w.Index]:
+			if undirected {
+				// Reverse direction of a back edge already classified.
+				continue
+			}
+			kind = ForwardEdge
+		default:
+			kind = CrossEdge
+		}
+		d.Arcs = append(d.Arcs, ClassifiedArc{Arc: arc, Kind: kind})
+		if kind == TreeEdge {
+			d.parent[w.Index] = arc.Edge
+			d.visit(w, out)
+		}
+	}
+	d.post[v.Index] = len(d.Postorder)
+	d.Postorder = append(d.Postorder, v)
+}
+
+// ReversePostorder returns a new slice containing the visited nodes in reverse
+// postorder.
+func (d *DFS) ReversePostorder() []*Node {
+	n := len(d.Postorder)
+	nodes := make([]*Node, n)
+	for i, node := range d.Postorder {
+		nodes[n-1-i] = node
+	}
+	return nodes
+}
+
+// Visited reports whether node was reached by the traversal.
+func (d *DFS) Visited(node *Node) bool {
+	return d.pre[node.Index] != -1
+}
+
+// PreNum returns the preorder number of node, or -1 if not visited.
+func (d *DFS) PreNum(node *Node) int {
+	return d.pre[node.Index]
+}
+
+// PostNum returns the postorder number of node, or -1 if not visited.
+func (d *DFS) PostNum(node *Node) int {
+	return d.post[node.Index]
+}
+
+// RPONum returns the reverse postorder number of node, or -1 if not visited.
+func (d *DFS) RPONum(node *Node) int {
+	if !d.Visited(node) {
+		return -1
+	}
+	return len(d.Postorder) - 1 - d.post[node.Index]
+}
+
+// ArcsOfKind returns the visited arcs of the given kind.
+func (d *DFS) ArcsOfKind(kind EdgeKind) []Arc {
+	var arcs []Arc
+	for _, arc := range d.Arcs {
+		if arc.Kind == kind {
+			arcs = append(arcs, arc.Arc)
+		}
+	}
+	return arcs
+}
+
+// BackEdges returns the back edges of the traversal. In a reducible flow
+// graph, these are the edges leading to loop headers.
+func (d *DFS) BackEdges() []Arc {
+	return d.ArcsOfKind(BackEdge)
+}
@@ -0,0 +1,88 @@
+package dot
+
+import (
+	"fmt"
+	"testing"
+)
+
+func nodeNames(nodes []*Node) string {
+	return fmt.Sprint(nodes)
+}
+
+func TestDFS(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a [label=entry];
+		a -> b;
+		b -> c;
+		c -> b;
+		a -> c;
+		b -> d;
+		c -> d;
+	}`))
+	check(t, err)
+	d := g.DFS(g.Nodes.Lookup["a"])
+	assert(t, "preorder", nodeNames(d.Preorder), "[a b c d]")
+	assert(t, "postorder", nodeNames(d.Postorder), "[d c b a]")
+	assert(t, "reverse postorder", nodeNames(d.ReversePostorder()), "[a b c d]")
+	want := map[string]EdgeKind{
+		"a->b": TreeEdge,
+		"b->c": TreeEdge,
+		"c->b": BackEdge,
+		"c->d": TreeEdge,
+		"b->d": ForwardEdge,
+		"a->c": ForwardEdge,
+	}
+	assert(t, "number of arcs", len(d.Arcs), len(want))
+	for _, arc := range d.Arcs {
+		key := arc.Src.Name + "->" + arc.Dst.Name
+		assert(t, key, arc.Kind, want[key])
+	}
+	back := d.BackEdges()
+	assert(t, "number of back edges", len(back), 1)
+	assert(t, "back edge", back[0].Dst.Name, "b")
+}
+
+func TestDFSCrossEdge(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a [label=entry];
+		a -> b;
+		a -> c;
+		c -> b;
+		e -> a;
+	}`))
+	check(t, err)
+	d := g.DFS(g.Nodes.Lookup["a"])
+	assert(t, "preorder", nodeNames(d.Preorder), "[a b c]")
+	assert(t, "e visited", d.Visited(g.Nodes.Lookup["e"]), false)
+	assert(t, "e preorder number", d.PreNum(g.Nodes.Lookup["e"]), -1)
+	assert(t, "c reverse postorder number", d.RPONum(g.Nodes.Lookup["c"]), 1)
+	cross := d.ArcsOfKind(CrossEdge)
+	assert(t, "number of cross edges", len(cross), 1)
+	assert(t, "cross edge", cross[0].Src.Name+"->"+cross[0].Dst.Name, "c->b")
+}
+
+func TestDFSUndirected(t *testing.T) {
+	g := NewGraph()
+	g.SetName("G")
+	for _, name := range []string{"a", "b", "c"} {
+		g.AddNode("G", name, nil)
+	}
+	g.AddEdge("a", "b", false, nil)
+	g.AddEdge("b", "c", false, nil)
+	g.AddEdge("c", "a", false, nil)
+	d := g.DFS(g.Nodes.Lookup["a"])
+	assert(t, "preorder", nodeNames(d.Preorder), "[a b c]")
+	assert(t, "number of arcs", len(d.Arcs), 3)
+	assert(t, "number of tree edges", len(d.ArcsOfKind(TreeEdge)), 2)
+	assert(t, "number of back edges", len(d.BackEdges()), 1)
+}
+
+func TestArcsNestedSubGraph(t *testing.T) {
+	g, err := Read([]byte(`digraph { a -> subgraph s { subgraph t { b } c } }`))
+	check(t, err)
+	var arcs []string
+	for _, arc := range g.Arcs() {
+		arcs = append(arcs, arc.Src.Name+"->"+arc.Dst.Name)
+	}
+	assert(t, "arcs", fmt.Sprint(arcs), "[a->b a->c]")
+}
@@ -0,0 +1,128 @@
+package dot
+
+// This file defines the transitive reduction and closure of graphs, akin to
+// the tred tool of Graphviz.
+
+import "math/big"
+
+// condensation is the directed acyclic graph of strongly connected components
+// of a graph.
+type condensation struct {
+	// Strongly connected components, in reverse topological order.
+	comps [][]*Node
+	// Component of each node, indexed by Node.Index.
+	compOf []int
+	// Distinct successor components of each component.
+	succs [][]int
+	// Components reachable from each component through at least one arc,
+	// represented as a bit-set of component indices.
+	reach []big.Int
+	// cyclic reports whether each component contains a cycle.
+	cyclic []bool
+}
+
+// newCondensation returns the condensation of the given graph.
+func newCondensation(g *Graph) *condensation {
+	comps := g.StronglyConnectedComponents()
+	c := &condensation{
+		comps:  comps,
+		compOf: make([]int, len(g.Nodes.Nodes)),
+		succs:  make([][]int, len(comps)),
+		reach:  make([]big.Int, len(comps)),
+		cyclic: make([]bool, len(comps)),
+	}
+	for i, comp := range comps {
+		for _, node := range comp {
+			c.compOf[node.Index] = i
+		}
+		c.cyclic[i] = len(comp) > 1
+	}
+	seen := make(map[[2]int]bool)
+	for _, arc := range g.Arcs() {
+		from, to := c.compOf[arc.Src.Index], c.compOf[arc.Dst.Index]
+		if from == to {
+			if arc.Src == arc.Dst {
+				c.cyclic[from] = true
+			}
+			continue
+		}
+		if key := [2]int{from, to}; !seen[key] {
+			seen[key] = true
+			c.succs[from] = append(c.succs[from], to)
+		}
+	}
+	// Successor components always precede their predecessors in reverse
+	// topological order.
+	for i := range comps {
+		for _, succ := range c.succs[i] {
+			c.reach[i].SetBit(&c.reach[i], succ, 1)
+			c.reach[i].Or(&c.reach[i], &c.reach[succ])
+		}
+	}
+	return c
+}
+
+// redundant reports whether the arc from component from to component to is
+// implied by another path between the two components.
+func (c *condensation) redundant(from, to int) bool {
+	for _, succ := range c.succs[from] {
+		if succ != to && c.reach[succ].Bit(to) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitiveReduction returns a new graph with the same reachability relation
+// as g and as few edges as possible. Node, edge and graph attributes are
+// retained.
+//
+// Edges to or from subgraphs are expanded into edges between nodes. As the
+// transitive reduction of a cyclic graph is not unique, each edge within a
+// strongly connected component is retained, and one edge is retained between
+// each pair of components connected by a non-redundant arc.
+func TransitiveReduction(g *Graph) *Graph {
+	c := newCondensation(g)
+	r := copyGraph(g, nil)
+	edges := newArcEdges(r)
+	kept := make(map[[2]int]bool)
+	for _, arc := range g.Arcs() {
+		from, to := c.compOf[arc.Src.Index], c.compOf[arc.Dst.Index]
+		if from != to {
+			key := [2]int{from, to}
+			if kept[key] || c.redundant(from, to) {
+				continue
+			}
+			kept[key] = true
+		}
+		edges.add(arc)
+	}
+	linkNodes(r)
+	return r
+}
+
+// TransitiveClosure returns a new graph with an edge from each node to every
+// node reachable from it in g. Node, edge and graph attributes are retained;
+// added edges have no attributes.
+//
+// Edges to or from subgraphs are expanded into edges between nodes.
+func TransitiveClosure(g *Graph) *Graph {
+	c := newCondensation(g)
+	r := copyGraph(g, nil)
+	edges := newArcEdges(r)
+	for _, arc := range g.Arcs() {
+		edges.add(arc)
+	}
+	for _, src := range g.Nodes.Nodes {
+		from := c.compOf[src.Index]
+		for _, dst := range g.Nodes.Nodes {
+			to := c.compOf[dst.Index]
+			if c.reach[from].Bit(to) == 1 || (from == to && c.cyclic[from]) {
+				edge := &Edge{Src: src.Name, Dst: dst.Name, Dir: g.Directed}
+				edges.add(Arc{Src: src, Dst: dst, Edge: edge})
+			}
+		}
+	}
+	linkNodes(r)
+	return r
+}
@@ -0,0 +1,53 @@
+package dot
+
+import (
+	"fmt"
+	"testing"
+)
+
+// edgeNames returns the edges of g as a string, in edge order.
+func edgeNames(g *Graph) string {
+	var names []string
+	for _, edge := range g.Edges.Edges {
+		names = append(names, edge.Src+"->"+edge.Dst)
+	}
+	return fmt.Sprint(names)
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a -> b [color=red];
+		b -> c;
+		a -> c;
+		c -> d;
+		a -> d;
+		d -> e;
+		e -> d;
+		d -> f;
+		e -> f;
+		a -> {b f};
+		b [shape=box];
+	}`))
+	check(t, err)
+	r := TransitiveReduction(g)
+	assert(t, "edges", edgeNames(r), "[a->b b->c c->d d->e e->d d->f]")
+	assert(t, "edge attribute", r.Edges.SrcToDsts["a"]["b"].Attrs["color"], "red")
+	assert(t, "node attribute", r.Nodes.Lookup["b"].Attrs["shape"], "box")
+	assert(t, "successors of a", nodeNames(r.Nodes.Lookup["a"].Succs), "[b]")
+	// The input graph is left unmodified.
+	assert(t, "input edges", len(g.Edges.Edges), 10)
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	g, err := Read([]byte(`digraph G {
+		a -> b [color=red];
+		b -> c;
+		c -> b;
+	}`))
+	check(t, err)
+	c := TransitiveClosure(g)
+	assert(t, "edges", edgeNames(c), "[a->b b->c c->b a->c b->b c->c]")
+	assert(t, "edge attribute", c.Edges.SrcToDsts["a"]["b"].Attrs["color"], "red")
+	assert(t, "has edge", c.HasEdge("a", "c"), true)
+	assert(t, "has edge", c.HasEdge("c", "a"), false)
+}
@@ -0,0 +1,393 @@
+package dot
+
+// This file defines typed accessors of Graphviz attributes, validated against
+// the attribute schema.
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// attrValue returns the value of the named attribute, or its default
+// value if absent.
+func attrValue(attrs Attrs, name string) string {
+	if value, ok := attrs[name]; ok {
+		return value
+	}
+	if spec, ok := LookupAttr(name); ok {
+		return spec.Default
+	}
+	return ""
+}
+
+// elemAttrValue returns the value of the named attribute of an element in the
+// given context, or its default value if absent. Only nodes have a default
+// label.
+func elemAttrValue(attrs Attrs, ctx Context, name string) string {
+	if _, ok := attrs[name]; !ok && name == "label" && ctx != ContextNode {
+		return ""
+	}
+	return attrValue(attrs, name)
+}
+
+// floatAttr returns the value of the named attribute as a floating-point
+// number, or its default value if absent.
+func floatAttr(attrs Attrs, name string) (float64, error) {
+	value := attrValue(attrs, name)
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of attribute %q; expected double", value, name)
+	}
+	return f, nil
+}
+
+// intAttr returns the value of the named attribute as an integer, or its
+// default value if absent.
+func intAttr(attrs Attrs, name string) (int, error) {
+	value := attrValue(attrs, name)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of attribute %q; expected int", value, name)
+	}
+	return i, nil
+}
+
+// boolAttr returns the value of the named attribute as a boolean, or its
+// default value if absent.
+func boolAttr(attrs Attrs, name string) (bool, error) {
+	value := attrValue(attrs, name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := parseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q of attribute %q; expected bool", value, name)
+	}
+	return b, nil
+}
+
+// enumAttr returns the value of the named attribute, or its default value if
+// absent, validated against the attribute schema.
+func enumAttr(attrs Attrs, name string) (string, error) {
+	value := attrValue(attrs, name)
+	if value == "" {
+		return "", nil
+	}
+	spec, _ := LookupAttr(name)
+	if err := spec.Validate(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// styleAttr returns the items of the style attribute.
+func styleAttr(attrs Attrs) ([]StyleItem, error) {
+	return parseStyle(attrValue(attrs, "style"))
+}
+
+// setAttr sets the named attribute to the given unquoted value, after
+// validating it against the attribute schema for the given context.
+func setAttr(attrs *Attrs, ctx Context, name, value string) error {
+	if err := ValidateAttr(ctx, name, value); err != nil {
+		return err
+	}
+	if *attrs == nil {
+		*attrs = NewAttrs()
+	}
+	(*attrs)[name] = value
+	return nil
+}
+
+// formatFloat returns the shortest representation of f.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Attr returns the unquoted value of the named attribute of the node, or its
+// default value if absent.
+func (n *Node) Attr(name string) string {
+	return attrValue(n.Attrs, name)
+}
+
+// SetAttr sets the named attribute of the node to the given unquoted value,
+// after validating it against the attribute schema.
+func (n *Node) SetAttr(name, value string) error {
+	return setAttr(&n.Attrs, ContextNode, name, value)
+}
+
+// Shape returns the shape of the node.
+func (n *Node) Shape() (string, error) {
+	return enumAttr(n.Attrs, "shape")
+}
+
+// SetShape sets the shape of the node.
+func (n *Node) SetShape(shape string) error {
+	return n.SetAttr("shape", shape)
+}
+
+// Label returns the label of the node.
+func (n *Node) Label() string {
+	return n.Attr("label")
+}
+
+// SetLabel sets the label of the node.
+func (n *Node) SetLabel(label string) error {
+	return n.SetAttr("label", label)
+}
+
+// Color returns the colour of the node.
+func (n *Node) Color() string {
+	return n.Attr("color")
+}
+
+// SetColor sets the colour of the node.
+func (n *Node) SetColor(color string) error {
+	return n.SetAttr("color", color)
+}
+
+// Width returns the width of the node in inches.
+func (n *Node) Width() (float64, error) {
+	return floatAttr(n.Attrs, "width")
+}
+
+// SetWidth sets the width of the node in inches.
+func (n *Node) SetWidth(width float64) error {
+	return n.SetAttr("width", formatFloat(width))
+}
+
+// Height returns the height of the node in inches.
+func (n *Node) Height() (float64, error) {
+	return floatAttr(n.Attrs, "height")
+}
+
+// SetHeight sets the height of the node in inches.
+func (n *Node) SetHeight(height float64) error {
+	return n.SetAttr("height", formatFloat(height))
+}
+
+// Penwidth returns the width of the pen used to draw the node.
+func (n *Node) Penwidth() (float64, error) {
+	return floatAttr(n.Attrs, "penwidth")
+}
+
+// SetPenwidth sets the width of the pen used to draw the node.
+func (n *Node) SetPenwidth(penwidth float64) error {
+	return n.SetAttr("penwidth", formatFloat(penwidth))
+}
+
+// Fontsize returns the font size of the node in points.
+func (n *Node) Fontsize() (float64, error) {
+	return floatAttr(n.Attrs, "fontsize")
+}
+
+// SetFontsize sets the font size of the node in points.
+func (n *Node) SetFontsize(fontsize float64) error {
+	return n.SetAttr("fontsize", formatFloat(fontsize))
+}
+
+// Peripheries returns the number of peripheries of the node.
+func (n *Node) Peripheries() (int, error) {
+	return intAttr(n.Attrs, "peripheries")
+}
+
+// SetPeripheries sets the number of peripheries of the node.
+func (n *Node) SetPeripheries(peripheries int) error {
+	return n.SetAttr("peripheries", strconv.Itoa(peripheries))
+}
+
+// Style returns the style items of the node.
+func (n *Node) Style() ([]StyleItem, error) {
+	return styleAttr(n.Attrs)
+}
+
+// Attr returns the unquoted value of the named attribute of the edge, or its
+// default value if absent.
+func (e *Edge) Attr(name string) string {
+	return elemAttrValue(e.Attrs, ContextEdge, name)
+}
+
+// SetAttr sets the named attribute of the edge to the given unquoted value,
+// after validating it against the attribute schema.
+func (e *Edge) SetAttr(name, value string) error {
+	return setAttr(&e.Attrs, ContextEdge, name, value)
+}
+
+// Label returns the label of the edge.
+func (e *Edge) Label() string {
+	return e.Attr("label")
+}
+
+// SetLabel sets the label of the edge.
+func (e *Edge) SetLabel(label string) error {
+	return e.SetAttr("label", label)
+}
+
+// Color returns the colour of the edge.
+func (e *Edge) Color() string {
+	return e.Attr("color")
+}
+
+// SetColor sets the colour of the edge.
+func (e *Edge) SetColor(color string) error {
+	return e.SetAttr("color", color)
+}
+
+// Penwidth returns the width of the pen used to draw the edge.
+func (e *Edge) Penwidth() (float64, error) {
+	return floatAttr(e.Attrs, "penwidth")
+}
+
+// SetPenwidth sets the width of the pen used to draw the edge.
+func (e *Edge) SetPenwidth(penwidth float64) error {
+	return e.SetAttr("penwidth", formatFloat(penwidth))
+}
+
+// Weight returns the weight of the edge.
+func (e *Edge) Weight() (float64, error) {
+	return floatAttr(e.Attrs, "weight")
+}
+
+// SetWeight sets the weight of the edge.
+func (e *Edge) SetWeight(weight float64) error {
+	return e.SetAttr("weight", formatFloat(weight))
+}
+
+// Minlen returns the minimum edge length in ranks.
+func (e *Edge) Minlen() (int, error) {
+	return intAttr(e.Attrs, "minlen")
+}
+
+// SetMinlen sets the minimum edge length in ranks.
+func (e *Edge) SetMinlen(minlen int) error {
+	return e.SetAttr("minlen", strconv.Itoa(minlen))
+}
+
+// Constraint reports whether the edge is used in ranking the nodes.
+func (e *Edge) Constraint() (bool, error) {
+	return boolAttr(e.Attrs, "constraint")
+}
+
+// SetConstraint sets whether the edge is used in ranking the nodes.
+func (e *Edge) SetConstraint(constraint bool) error {
+	return e.SetAttr("constraint", strconv.FormatBool(constraint))
+}
+
+// DirType returns the kind of arrowheads drawn for the edge, as specified by
+// the dir attribute. The default depends on the graph type; i.e. "forward" for
+// directed and "none" for undirected edges.
+func (e *Edge) DirType() (string, error) {
+	if _, ok := e.Attrs["dir"]; !ok && !e.Dir {
+		return "none", nil
+	}
+	return enumAttr(e.Attrs, "dir")
+}
+
+// SetDirType sets the kind of arrowheads drawn for the edge.
+func (e *Edge) SetDirType(dir string) error {
+	return e.SetAttr("dir", dir)
+}
+
+// Arrowhead returns the style of the arrowhead at the head of the edge.
+func (e *Edge) Arrowhead() (string, error) {
+	return enumAttr(e.Attrs, "arrowhead")
+}
+
+// SetArrowhead sets the style of the arrowhead at the head of the edge.
+func (e *Edge) SetArrowhead(arrow string) error {
+	return e.SetAttr("arrowhead", arrow)
+}
+
+// Arrowtail returns the style of the arrowhead at the tail of the edge.
+func (e *Edge) Arrowtail() (string, error) {
+	return enumAttr(e.Attrs, "arrowtail")
+}
+
+// SetArrowtail sets the style of the arrowhead at the tail of the edge.
+func (e *Edge) SetArrowtail(arrow string) error {
+	return e.SetAttr("arrowtail", arrow)
+}
+
+// Style returns the style items of the edge.
+func (e *Edge) Style() ([]StyleItem, error) {
+	return styleAttr(e.Attrs)
+}
+
+// Attr returns the unquoted value of the named attribute of the graph, or its
+// default value if absent.
+func (g *Graph) Attr(name string) string {
+	return elemAttrValue(g.Attrs, ContextGraph, name)
+}
+
+// SetAttr sets the named attribute of the graph to the given unquoted value,
+// after validating it against the attribute schema.
+func (g *Graph) SetAttr(name, value string) error {
+	return setAttr(&g.Attrs, ContextGraph, name, value)
+}
+
+// Label returns the label of the graph.
+func (g *Graph) Label() string {
+	return g.Attr("label")
+}
+
+// SetLabel sets the label of the graph.
+func (g *Graph) SetLabel(label string) error {
+	return g.SetAttr("label", label)
+}
+
+// Rankdir returns the direction of the graph layout.
+func (g *Graph) Rankdir() (string, error) {
+	return enumAttr(g.Attrs, "rankdir")
+}
+
+// SetRankdir sets the direction of the graph layout.
+func (g *Graph) SetRankdir(rankdir string) error {
+	return g.SetAttr("rankdir", rankdir)
+}
+
+// Nodesep returns the minimum space between adjacent nodes in inches.
+func (g *Graph) Nodesep() (float64, error) {
+	return floatAttr(g.Attrs, "nodesep")
+}
+
+// SetNodesep sets the minimum space between adjacent nodes in inches.
+func (g *Graph) SetNodesep(nodesep float64) error {
+	return g.SetAttr("nodesep", formatFloat(nodesep))
+}
+
+// Attr returns the unquoted value of the named attribute of the subgraph, or
+// its default value if absent.
+func (s *SubGraph) Attr(name string) string {
+	return elemAttrValue(s.Attrs, SubGraphContext(s.Name), name)
+}
+
+// SetAttr sets the named attribute of the subgraph to the given unquoted
+// value, after validating it against the attribute schema.
+func (s *SubGraph) SetAttr(name, value string) error {
+	return setAttr(&s.Attrs, SubGraphContext(s.Name), name, value)
+}
+
+// Label returns the label of the subgraph.
+func (s *SubGraph) Label() string {
+	return s.Attr("label")
+}
+
+// SetLabel sets the label of the subgraph.
+func (s *SubGraph) SetLabel(label string) error {
+	return s.SetAttr("label", label)
+}
+
+// Rank returns the rank constraint on the nodes of the subgraph.
+func (s *SubGraph) Rank() (string, error) {
+	return enumAttr(s.Attrs, "rank")
+}
+
+// SetRank sets the rank constraint on the nodes of the subgraph.
+func (s *SubGraph) SetRank(rank string) error {
+	return s.SetAttr("rank", rank)
+}
@@ -0,0 +1,290 @@
+package dot
+
+// This file defines the unmarshalling of graphs into Go values.
+
+import (
+	"encoding"
+	"fmt"
+	"image/color"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// unmarshalOpts are the options of "dot" struct tags used by Unmarshal.
+var unmarshalOpts = map[string]bool{
+	"name":     true,
+	"directed": true,
+	"nodes":    true,
+	"edges":    true,
+	"src":      true,
+	"dst":      true,
+	"srcport":  true,
+	"dstport":  true,
+	"attrs":    true,
+	"default":  true,
+}
+
+// Unmarshal parses the given DOT graph and stores it in the struct pointed to
+// by v, as with UnmarshalGraph.
+func Unmarshal(data []byte, v interface{}) error {
+	g, err := Read(data)
+	if err != nil {
+		return err
+	}
+	return UnmarshalGraph(g, v)
+}
+
+// UnmarshalGraph stores the graph in the struct pointed to by v. The fields of
+// v receive the attributes of the graph, and the nodes and edges of the graph
+// are stored in fields of slices or maps of structs, which receive their
+// attributes.
+//
+// Fields receive the attribute given by the leading name of their "dot"
+// struct tag, or the lowercase field name if none, as in `dot:"shape"`.
+// Missing attributes are skipped, unless the tag has the default option, as in
+// `dot:"shape,default"`, which stores the default value of the Graphviz schema;
+// e.g. ellipse for the shape of nodes, and \N for the label of nodes. Values
+// are converted to the type of the field, which is either a string, bool,
+// integer or floating-point number, color.RGBA, a type implementing
+// encoding.TextUnmarshaler, a slice of comma-separated values of these types,
+// or a pointer to one of these types.
+// Fields with a tag of "-" are skipped, and the following options of tags, as
+// in `dot:",nodes"`, store other values:
+//
+//	name        name of the graph or node
+//	directed    whether the graph or edge is directed
+//	nodes       nodes of the graph; a slice of structs, or a map of structs
+//	            keyed by node name
+//	edges       edges of the graph; a slice of structs
+//	src, dst    source and destination of the edge
+//	srcport     source and destination ports of the edge, without leading
+//	dstport     colons
+//	attrs       all attributes, in a map[string]string
+func UnmarshalGraph(g *Graph, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to unmarshal graph into %T; expected non-nil pointer to struct", v)
+	}
+	return unmarshalStruct(rv.Elem(), graphElem(g.Name), ContextGraph, g.Attrs, func(f reflect.Value, opt string) (bool, error) {
+		switch opt {
+		case "name":
+			return true, setValue(f, g.Name, "")
+		case "directed":
+			return true, setValue(f, strconv.FormatBool(g.Directed), "")
+		case "nodes":
+			return true, unmarshalNodes(g, f)
+		case "edges":
+			return true, unmarshalEdges(g, f)
+		}
+		return false, nil
+	})
+}
+
+// unmarshalNodes stores the nodes of the graph in the given slice or map.
+func unmarshalNodes(g *Graph, f reflect.Value) error {
+	t, err := elemStruct(f.Type(), true)
+	if err != nil {
+		return err
+	}
+	if f.Kind() == reflect.Map && f.IsNil() {
+		f.Set(reflect.MakeMap(f.Type()))
+	}
+	for _, node := range g.Nodes.Nodes {
+		v := reflect.New(t)
+		err := unmarshalStruct(v.Elem(), "node "+Quote(node.Name), ContextNode, node.Attrs, func(f reflect.Value, opt string) (bool, error) {
+			if opt == "name" {
+				return true, setValue(f, node.Name, "")
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		if f.Kind() == reflect.Map {
+			f.SetMapIndex(reflect.ValueOf(node.Name).Convert(f.Type().Key()), elemValue(f.Type(), v))
+		} else {
+			f.Set(reflect.Append(f, elemValue(f.Type(), v)))
+		}
+	}
+	return nil
+}
+
+// unmarshalEdges stores the edges of the graph in the given slice.
+func unmarshalEdges(g *Graph, f reflect.Value) error {
+	t, err := elemStruct(f.Type(), false)
+	if err != nil {
+		return err
+	}
+	for _, edge := range g.Edges.Edges {
+		v := reflect.New(t)
+		err := unmarshalStruct(v.Elem(), "edge "+edgeString(edge), ContextEdge, edge.Attrs, func(f reflect.Value, opt string) (bool, error) {
+			switch opt {
+			case "src":
+				return true, setValue(f, edge.Src, "")
+			case "dst":
+				return true, setValue(f, edge.Dst, "")
+			case "srcport":
+				return true, setValue(f, strings.TrimPrefix(edge.SrcPort, ":"), "")
+			case "dstport":
+				return true, setValue(f, strings.TrimPrefix(edge.DstPort, ":"), "")
+			case "directed":
+				return true, setValue(f, strconv.FormatBool(edge.Dir), "")
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.Append(f, elemValue(f.Type(), v)))
+	}
+	return nil
+}
+
+// elemStruct returns the struct type of the elements of the given slice, or
+// map keyed by strings if allowed, which are either structs or pointers to
+// structs.
+func elemStruct(t reflect.Type, allowMap bool) (reflect.Type, error) {
+	valid := t.Kind() == reflect.Slice || (allowMap && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String)
+	if valid {
+		elem := t.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			return elem, nil
+		}
+	}
+	if allowMap {
+		return nil, fmt.Errorf("unable to store nodes in %v; expected slice or map of structs", t)
+	}
+	return nil, fmt.Errorf("unable to store edges in %v; expected slice of structs", t)
+}
+
+// elemValue returns the given pointer to a struct as an element of the given
+// slice or map type.
+func elemValue(t reflect.Type, v reflect.Value) reflect.Value {
+	if t.Elem().Kind() == reflect.Ptr {
+		return v
+	}
+	return v.Elem()
+}
+
+// unmarshalStruct stores the given attributes of an element in the given
+// context in the fields of v. Fields with tag options are stored by special,
+// which reports whether the option is valid for the element.
+func unmarshalStruct(v reflect.Value, elem string, ctx Context, attrs Attrs, special func(f reflect.Value, opt string) (bool, error)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("dot")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name, opt, def := parts[0], "", false
+		for _, part := range parts[1:] {
+			switch {
+			case part == "default":
+				def = true
+			case unmarshalOpts[part]:
+				opt = part
+			}
+		}
+		f := v.Field(i)
+		switch opt {
+		case "":
+		case "attrs":
+			if f.Type() != reflect.TypeOf(map[string]string(nil)) {
+				return fmt.Errorf("%s: unable to store attributes in field %s of type %v; expected map[string]string", elem, field.Name, f.Type())
+			}
+			f.Set(reflect.ValueOf(map[string]string(attrs.Copy())))
+			continue
+		default:
+			ok, err := special(f, opt)
+			if err != nil {
+				return fmt.Errorf("%s: field %s: %v", elem, field.Name, err)
+			}
+			if !ok {
+				return fmt.Errorf("%s: option %q of field %s not valid", elem, opt, field.Name)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		value, ok := attrs[name]
+		if !ok && def {
+			value = elemAttrValue(attrs, ctx, name)
+		}
+		if value == "" {
+			continue
+		}
+		if err := setValue(f, value, attrs["colorscheme"]); err != nil {
+			return fmt.Errorf("%s: invalid value %q of attribute %q; %v", elem, value, name, err)
+		}
+	}
+	return nil
+}
+
+// setValue stores the given value in f, converted to its type. Colours are
+// resolved using the given colour scheme.
+func setValue(f reflect.Value, value, scheme string) error {
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+		return setValue(f.Elem(), value, scheme)
+	}
+	if u, ok := f.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	if f.Type() == reflect.TypeOf(color.RGBA{}) {
+		c, err := ParseColor(value, scheme)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(c))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(value, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	case reflect.Slice:
+		parts := strings.Split(value, ",")
+		s := reflect.MakeSlice(f.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(s.Index(i), strings.TrimSpace(part), scheme); err != nil {
+				return err
+			}
+		}
+		f.Set(s)
+	default:
+		return fmt.Errorf("unsupported type %v", f.Type())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package dot
+
+import (
+	"image/color"
+	"net"
+	"testing"
+)
+
+type topologyService struct {
+	Name     string `dot:",name"`
+	Label    string
+	Text     string `dot:"label,default"`
+	Shape    string `dot:"shape,default"`
+	Replicas int
+	Critical *bool
+	Color    color.RGBA        `dot:"color"`
+	Zones    []string          `dot:"zones"`
+	Addr     net.IP            `dot:"addr"`
+	Attrs    map[string]string `dot:",attrs"`
+	Ignored  string            `dot:"-"`
+}
+
+type topologyDependency struct {
+	From    string  `dot:",src"`
+	To      string  `dot:",dst"`
+	Port    string  `dot:",dstport"`
+	Weight  float64 `dot:"weight"`
+	Timeout uint16  `dot:"timeout_ms"`
+}
+
+type topology struct {
+	Name     string                     `dot:",name"`
+	Directed bool                       `dot:",directed"`
+	Label    string                     `dot:"label,omit"`
+	Services []*topologyService         `dot:",nodes"`
+	ByName   map[string]topologyService `dot:",nodes"`
+	Deps     []topologyDependency       `dot:",edges"`
+}
+
+const topologySource = `digraph prod {
+	label="production";
+	node [colorscheme=set13];
+	gateway [shape=box, replicas=3, critical=true, color=2, zones="eu-1, eu-2", addr="10.0.0.1"];
+	auth [label="auth service"];
+	gateway -> auth:grpc [weight=2.5, timeout_ms=250];
+}`
+
+func TestUnmarshal(t *testing.T) {
+	var top topology
+	check(t, Unmarshal([]byte(topologySource), &top))
+	assert(t, "name", top.Name, "prod")
+	assert(t, "directed", top.Directed, true)
+	assert(t, "label", top.Label, "production")
+	assert(t, "services", len(top.Services), 2)
+	gw := top.Services[0]
+	assert(t, "node name", gw.Name, "gateway")
+	assert(t, "missing label", gw.Label, "")
+	assert(t, "default label", gw.Text, `\N`)
+	assert(t, "shape", gw.Shape, "box")
+	assert(t, "replicas", gw.Replicas, 3)
+	assert(t, "critical", *gw.Critical, true)
+	assert(t, "brewer color", gw.Color, color.RGBA{0x37, 0x7e, 0xb8, 0xff})
+	assert(t, "zones", len(gw.Zones), 2)
+	assert(t, "zone", gw.Zones[1], "eu-2")
+	assert(t, "text unmarshaler", gw.Addr.String(), "10.0.0.1")
+	assert(t, "attrs", gw.Attrs["replicas"], "3")
+	auth := top.ByName["auth"]
+	assert(t, "map label", auth.Label, "auth service")
+	assert(t, "label with default", auth.Text, "auth service")
+	assert(t, "default shape", auth.Shape, "ellipse")
+	assert(t, "missing custom attribute", auth.Critical == nil, true)
+	assert(t, "deps", len(top.Deps), 1)
+	assert(t, "dep", top.Deps[0], topologyDependency{From: "gateway", To: "auth", Port: "grpc", Weight: 2.5, Timeout: 250})
+
+	// The tags of Unmarshal are also valid for Marshal.
+	_, err := Marshal(&top)
+	check(t, err)
+
+	invalid := map[string]interface{}{
+		"not a pointer": top,
+		"invalid integer": &struct {
+			Services []struct{ Replicas int } `dot:",nodes"`
+		}{},
+		"invalid nodes": &struct {
+			Nodes []string `dot:",nodes"`
+		}{},
+		"invalid option": &struct {
+			From string `dot:",src"`
+		}{},
+		"unsupported": &struct{ Label complex128 }{},
+	}
+	src := `digraph { label=x; a [replicas=many] }`
+	for desc, v := range invalid {
+		if err := Unmarshal([]byte(src), v); err == nil {
+			t.Errorf("%s: expected error", desc)
+		}
+	}
+}
@@ -29,11 +29,11 @@ func newWriter(g *Graph) *writer {
 	return &writer{g, make(map[string]bool)}
 }
 
-func appendAttrs(list ast.StmtList, attrs Attrs) ast.StmtList {
+func appendAttrs(list ast.StmtList, attrs Attrs, html HTMLAttrs) ast.StmtList {
 	for _, name := range attrs.SortedNames() {
 		stmt := &ast.Attr{
-			Field: ast.Id(name),
-			Value: ast.Id(attrs[name]),
+			Field: ast.Id(Quote(name)),
+			Value: ast.Id(quoteValue(attrs[name], html[name])),
 		}
 		list = append(list, stmt)
 	}
@@ -44,8 +44,8 @@ func (this *writer) newSubGraph(name string) *ast.SubGraph {
 	sub := this.SubGraphs.SubGraphs[name]
 	this.writtenLocations[sub.Name] = true
 	s := &ast.SubGraph{}
-	s.Id = ast.Id(sub.Name)
-	s.StmtList = appendAttrs(s.StmtList, sub.Attrs)
+	s.Id = ast.Id(Quote(sub.Name))
+	s.StmtList = appendAttrs(s.StmtList, sub.Attrs, sub.HTMLAttrs)
 	children := this.Relations.SortedChildren(name)
 	for _, child := range children {
 		s.StmtList = append(s.StmtList, this.newNodeStmt(child))
@@ -55,16 +55,39 @@ func (this *writer) newSubGraph(name string) *ast.SubGraph {
 
 func (this *writer) newNodeId(name string, port string) *ast.NodeId {
 	node := this.Nodes.Lookup[name]
-	return ast.MakeNodeId(node.Name, port)
+	id, compass := splitPort(port)
+	return &ast.NodeId{
+		Id:   ast.Id(Quote(node.Name)),
+		Port: ast.Port{Id1: ast.Id(quotePortId(id)), Id2: ast.Id(compass)},
+	}
+}
+
+// quotePortId returns the given port ID quoted if required, or the empty
+// string if none.
+func quotePortId(id string) string {
+	if id == "" {
+		return ""
+	}
+	return Quote(id)
+}
+
+// quoteAttrs returns the given attributes with their names and values quoted
+// if required. The values of the given HTML attributes are not quoted.
+func quoteAttrs(attrs Attrs, html HTMLAttrs) map[string]string {
+	quoted := make(map[string]string)
+	for name, value := range attrs {
+		quoted[Quote(name)] = quoteValue(value, html[name])
+	}
+	return quoted
 }
 
 func (this *writer) newNodeStmt(name string) *ast.NodeStmt {
 	node := this.Nodes.Lookup[name]
-	id := ast.MakeNodeId(node.Name, "")
+	id := this.newNodeId(name, "")
 	this.writtenLocations[node.Name] = true
 	return &ast.NodeStmt{
 		id,
-		ast.PutMap(node.Attrs),
+		ast.PutMap(quoteAttrs(node.Attrs, node.HTMLAttrs)),
 	}
 }
 
@@ -91,7 +114,7 @@ func (this *writer) newEdgeStmt(edge *Edge) *ast.EdgeStmt {
 				dst,
 			},
 		},
-		Attrs: ast.PutMap(edge.Attrs),
+		Attrs: ast.PutMap(quoteAttrs(edge.Attrs, edge.HTMLAttrs)),
 	}
 	return stmt
 }
@@ -100,9 +123,11 @@ func (this *writer) Write() *ast.Graph {
 	t := &ast.Graph{}
 	t.Strict = this.Strict
 	t.Type = ast.GraphType(this.Directed)
-	t.Id = ast.Id(this.Name)
+	if this.Name != "" {
+		t.Id = ast.Id(Quote(this.Name))
+	}
 
-	t.StmtList = appendAttrs(t.StmtList, this.Attrs)
+	t.StmtList = appendAttrs(t.StmtList, this.Attrs, this.HTMLAttrs)
 
 	nodes := this.Nodes.DomSorted()
 	for _, n := range nodes {